@@ -0,0 +1,66 @@
+package errs
+
+import (
+	"errors"
+	"io"
+)
+
+//Annotate function wraps *errp with context informations if *errp is not nil.
+//Annotate function is called by defer statement with named result error,
+//and records the function that returns *errp (not the deferred function) as "function" context.
+//
+//	func open(path string) (f *os.File, err error) {
+//		defer errs.Annotate(&err, errs.WithContext("path", path))
+//		...
+//	}
+func Annotate(errp *error, opts ...ErrorContextFunc) {
+	if errp == nil || *errp == nil {
+		return
+	}
+	*errp = newError(*errp, true, 2, append([]ErrorContextFunc{withDeferred()}, opts...)...)
+}
+
+//Close function closes c and folds the error of Close method into *errp.
+//Close function is called by defer statement with named result error.
+//If *errp is nil, *errp is set to the error of Close method.
+//Otherwise *errp is joined with the error of Close method as secondary error (see Join function),
+//so errors.Is function matches both errors, and the message and Cause function keep *errp first.
+//(If errp is nil, the error of Close method is discarded.)
+//
+//	func write(path string, data []byte) (err error) {
+//		f, err := os.Create(path)
+//		if err != nil {
+//			return errs.Wrap(err)
+//		}
+//		defer errs.Close(&err, f)
+//		...
+//	}
+func Close(errp *error, c io.Closer) {
+	if c == nil {
+		return
+	}
+	cerr := c.Close()
+	if cerr == nil || errp == nil {
+		return
+	}
+	if *errp == nil {
+		*errp = newError(cerr, true, 2, withDeferred())
+		return
+	}
+	*errp = newError(errors.Join(*errp, cerr), true, 2, withDeferred())
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,147 @@
+package errs
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func annotated(err error) (rerr error) {
+	defer Annotate(&rerr, WithContext("foo", "bar"))
+	return err
+}
+
+func annotatedInLoop(err error) (rerr error) {
+	for i := 0; i < 2; i++ {
+		defer Annotate(&rerr, WithContext("i", i))
+	}
+	return err
+}
+
+func annotatedPanic() (rerr error) {
+	defer Annotate(&rerr, WithContext("foo", "bar"))
+	defer Recover(&rerr)
+	panic("boom")
+}
+
+func TestAnnotate(t *testing.T) {
+	testCases := []struct {
+		fn   func() error
+		err  error
+		msg  string
+		name string
+		ctx  map[string]interface{}
+	}{
+		{fn: func() error { return annotated(nil) }, err: nil},
+		{fn: func() error { return annotated(os.ErrInvalid) }, err: os.ErrInvalid, msg: "invalid argument", name: "errs.annotated", ctx: map[string]interface{}{"foo": "bar"}},
+		{fn: func() error { return annotatedInLoop(os.ErrInvalid) }, err: os.ErrInvalid, msg: "invalid argument", name: "errs.annotatedInLoop", ctx: map[string]interface{}{"i": 0}},
+		{fn: annotatedPanic, err: ErrPanic, msg: "panic: boom", name: "errs.annotatedPanic", ctx: map[string]interface{}{"foo": "bar"}},
+	}
+	for _, tc := range testCases {
+		err := tc.fn()
+		if tc.err == nil {
+			if err != nil {
+				t.Errorf("Annotate() is \"%v\", want <nil>", err)
+			}
+			continue
+		}
+		if !errors.Is(err, tc.err) {
+			t.Errorf("Annotate() is \"%v\", want \"%v\"", err, tc.err)
+		}
+		if err.Error() != tc.msg {
+			t.Errorf("Annotate() is \"%v\", want \"%v\"", err, tc.msg)
+		}
+		e, ok := err.(*Error)
+		if !ok {
+			t.Errorf("Annotate() is %T, want *Error", err)
+			continue
+		}
+		if fn, _ := e.Context["function"].(string); !strings.HasSuffix(fn, tc.name) {
+			t.Errorf("Context[\"function\"] of Annotate() is %v, want %v", fn, tc.name)
+		}
+		for k, v := range tc.ctx {
+			if e.Context[k] != v {
+				t.Errorf("Context[%q] of Annotate() is %v, want %v", k, e.Context[k], v)
+			}
+		}
+	}
+}
+
+//testCloser is io.Closer that returns err.
+type testCloser struct {
+	err    error
+	closed bool
+}
+
+func (c *testCloser) Close() error {
+	c.closed = true
+	return c.err
+}
+
+var errClose = errors.New("close error")
+
+func closed(err error, c *testCloser) (rerr error) {
+	defer Close(&rerr, c)
+	return err
+}
+
+func TestClose(t *testing.T) {
+	testCases := []struct {
+		err    error
+		closer *testCloser
+		msg    string
+		causes []error
+		cause  error
+	}{
+		{err: nil, closer: &testCloser{}, msg: ""},
+		{err: os.ErrInvalid, closer: &testCloser{}, msg: "invalid argument", causes: []error{os.ErrInvalid}, cause: os.ErrInvalid},
+		{err: nil, closer: &testCloser{err: errClose}, msg: "close error", causes: []error{errClose}, cause: errClose},
+		{err: os.ErrInvalid, closer: &testCloser{err: errClose}, msg: "invalid argument\nclose error", causes: []error{os.ErrInvalid, errClose}, cause: os.ErrInvalid},
+		{err: New("query failed", WithCause(os.ErrInvalid)), closer: &testCloser{err: errClose}, msg: "query failed: invalid argument\nclose error", causes: []error{os.ErrInvalid, errClose}, cause: os.ErrInvalid},
+	}
+	for _, tc := range testCases {
+		err := closed(tc.err, tc.closer)
+		if !tc.closer.closed {
+			t.Errorf("Close() does not close %v", tc.closer)
+		}
+		if len(tc.msg) == 0 {
+			if err != nil {
+				t.Errorf("Close() is \"%v\", want <nil>", err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.msg {
+			t.Errorf("Close() is \"%v\", want \"%v\"", err, tc.msg)
+			continue
+		}
+		for _, c := range tc.causes {
+			if !errors.Is(err, c) {
+				t.Errorf("errors.Is(\"%v\", \"%v\") is false, want true", err, c)
+			}
+		}
+		if c := Cause(err); c != tc.cause {
+			t.Errorf("Cause(\"%v\") is \"%v\", want \"%v\"", err, c, tc.cause)
+		}
+		if e, ok := err.(*Error); ok && tc.closer.err != nil {
+			if fn, _ := e.Context["function"].(string); !strings.HasSuffix(fn, "errs.closed") {
+				t.Errorf("Context[\"function\"] of Close() is %v, want %v", fn, "errs.closed")
+			}
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,102 @@
+package errs
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+//CallerConfig type is global configuration of caller information recorded by New and Wrap functions.
+type CallerConfig struct {
+	//Disabled disables recording of caller information and stack trace. (for hot paths)
+	Disabled bool
+	//FileLine adds "file" and "line" context of the caller to "function" context.
+	FileLine bool
+	//ShortName records function name without package path (e.g. "errs.New" instead of "github.com/spiegel-im-spiegel/errs.New").
+	ShortName bool
+}
+
+var callerConfig atomic.Pointer[CallerConfig]
+
+//SetCallerConfig function sets global configuration of caller information.
+//The zero value of CallerConfig is the default configuration (only "function" context with full name, and stack trace).
+func SetCallerConfig(cfg CallerConfig) {
+	callerConfig.Store(&cfg)
+}
+
+//getCallerConfig returns global configuration of caller information. (internal)
+func getCallerConfig() CallerConfig {
+	if cfg := callerConfig.Load(); cfg != nil {
+		return *cfg
+	}
+	return CallerConfig{}
+}
+
+//functionName returns function name formatted by the configuration. (internal)
+func (cfg CallerConfig) functionName(name string) string {
+	if cfg.ShortName {
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			return name[i+1:]
+		}
+	}
+	return name
+}
+
+//captureOptions type is options of caller information while creating Error instance. (internal)
+type captureOptions struct {
+	disabled bool
+	skip     int
+	deferred bool
+}
+
+//WithSkip function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that skips stack frames for caller information and stack trace.
+//WithSkip(1) records the caller of the function that calls New or Wrap function. (for helper functions)
+func WithSkip(skip int) ErrorContextFunc {
+	return func(e *Error) {
+		if e.capture != nil && skip > 0 {
+			e.capture.skip += skip
+		}
+	}
+}
+
+//WithoutCaller function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that disables recording of caller information and stack trace.
+func WithoutCaller() ErrorContextFunc {
+	return func(e *Error) {
+		if e.capture != nil {
+			e.capture.disabled = true
+		}
+	}
+}
+
+//withDeferred returns ErrorContextFunc function value that records function running deferred function
+//(or panic site while panicking) as caller. (internal)
+func withDeferred() ErrorContextFunc {
+	return func(e *Error) {
+		if e.capture != nil {
+			e.capture.deferred = true
+		}
+	}
+}
+
+//setDefaultContext method sets context information if name is not set yet. (internal)
+func (e *Error) setDefaultContext(name string, value interface{}) {
+	if _, ok := e.Context[name]; !ok {
+		_ = e.SetContext(name, value)
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,120 @@
+package errs
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func helperWrap(err error) error {
+	return Wrap(err, WithSkip(1))
+}
+
+func helperWrapSkip(err error) error {
+	return WrapSkip(err, 1, WithContext("foo", "bar"))
+}
+
+func TestWithSkip(t *testing.T) {
+	testCases := []struct {
+		err error
+	}{
+		{err: helperWrap(os.ErrInvalid)},
+		{err: helperWrapSkip(os.ErrInvalid)},
+		{err: New("error", WithSkip(0))},
+	}
+	for _, tc := range testCases {
+		e, ok := tc.err.(*Error)
+		if !ok {
+			t.Errorf("error is %T, want *Error", tc.err)
+			continue
+		}
+		want := "github.com/spiegel-im-spiegel/errs.TestWithSkip"
+		if fname := e.Context["function"]; fname != want {
+			t.Errorf("Context[\"function\"] is %v, want %v", fname, want)
+		}
+		if st := e.StackTrace(); len(st) == 0 || st[0].Function != want {
+			t.Errorf("StackTrace() is %v, want starting with %v", st, want)
+		}
+	}
+	if err := WrapSkip(nil, 1); err != nil {
+		t.Errorf("WrapSkip(nil) is %v, want <nil>", err)
+	}
+}
+
+func TestWithoutCaller(t *testing.T) {
+	err := Wrap(os.ErrInvalid, WithoutCaller(), WithContext("foo", "bar"))
+	e := err.(*Error)
+	if _, ok := e.Context["function"]; ok {
+		t.Errorf("Context is %v, want no function", e.Context)
+	}
+	if st := e.StackTrace(); st != nil {
+		t.Errorf("StackTrace() is %v, want <nil>", st)
+	}
+	if str, want := EncodeJSON(err), `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"invalid argument"},"Context":{"foo":"bar"}}`; str != want {
+		t.Errorf("EncodeJSON() is %v, want %v", str, want)
+	}
+}
+
+func TestSetCallerConfig(t *testing.T) {
+	t.Cleanup(func() { SetCallerConfig(CallerConfig{}) })
+
+	testCases := []struct {
+		cfg      CallerConfig
+		function interface{}
+		file     bool
+	}{
+		{cfg: CallerConfig{}, function: "github.com/spiegel-im-spiegel/errs.TestSetCallerConfig", file: false},
+		{cfg: CallerConfig{ShortName: true}, function: "errs.TestSetCallerConfig", file: false},
+		{cfg: CallerConfig{FileLine: true}, function: "github.com/spiegel-im-spiegel/errs.TestSetCallerConfig", file: true},
+		{cfg: CallerConfig{Disabled: true}, function: nil, file: false},
+	}
+	for _, tc := range testCases {
+		SetCallerConfig(tc.cfg)
+		e := New("error").(*Error)
+		if fname := e.Context["function"]; fname != tc.function {
+			t.Errorf("Context[\"function\"] with %+v is %v, want %v", tc.cfg, fname, tc.function)
+		}
+		file, _ := e.Context["file"].(string)
+		line, _ := e.Context["line"].(int)
+		if ok := strings.HasSuffix(file, "caller_test.go") && line > 0; ok != tc.file {
+			t.Errorf("file:line with %+v is %v:%v, want %v", tc.cfg, file, line, tc.file)
+		}
+		if ok := e.StackTrace() != nil; ok == tc.cfg.Disabled {
+			t.Errorf("StackTrace() with %+v is %v", tc.cfg, e.StackTrace())
+		}
+	}
+}
+
+func TestCallerContextOverride(t *testing.T) {
+	e := New("error", WithContext("function", "custom")).(*Error)
+	if fname := e.Context["function"]; fname != "custom" {
+		t.Errorf("Context[\"function\"] is %v, want %v", fname, "custom")
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = New("error")
+	}
+}
+
+func BenchmarkNewWithoutCaller(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = New("error", WithoutCaller())
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
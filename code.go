@@ -0,0 +1,152 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+//Code type is error code of Error instance.
+//Error codes are declared by RegisterCode function.
+type Code string
+
+//Severity type is severity level of error code.
+type Severity int
+
+const (
+	SeverityUnknown Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+var severityNames = map[Severity]string{
+	SeverityUnknown:  "unknown",
+	SeverityInfo:     "info",
+	SeverityWarning:  "warning",
+	SeverityError:    "error",
+	SeverityCritical: "critical",
+}
+
+//String method returns name of Severity.
+//This method is a implementation of fmt.Stringer interface.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+//CodeInfo type is information of error code registered by RegisterCode function.
+type CodeInfo struct {
+	Code     Code
+	Message  string
+	Severity Severity
+	URL      string
+}
+
+var codeRegistry = struct {
+	sync.RWMutex
+	codes map[Code]CodeInfo
+}{codes: map[Code]CodeInfo{}}
+
+//RegisterCode function declares error code with default message, severity and documentation URL.
+//RegisterCode function panics if code is empty or already registered. (Declare error codes once at package initialization.)
+func RegisterCode(code Code, msg string, severity Severity, url string) Code {
+	if len(code) == 0 {
+		panic("errs: RegisterCode with empty code")
+	}
+	codeRegistry.Lock()
+	defer codeRegistry.Unlock()
+	if _, ok := codeRegistry.codes[code]; ok {
+		panic(fmt.Sprintf("errs: RegisterCode called twice for code %q", code))
+	}
+	codeRegistry.codes[code] = CodeInfo{Code: code, Message: msg, Severity: severity, URL: url}
+	return code
+}
+
+//LookupCode function returns information of registered error code.
+func LookupCode(code Code) (CodeInfo, bool) {
+	codeRegistry.RLock()
+	defer codeRegistry.RUnlock()
+	info, ok := codeRegistry.codes[code]
+	return info, ok
+}
+
+//Info method returns information of registered error code.
+//If the code is not registered, Info method returns CodeInfo with the code only.
+func (c Code) Info() CodeInfo {
+	if info, ok := LookupCode(c); ok {
+		return info
+	}
+	return CodeInfo{Code: c}
+}
+
+//String method returns error code string.
+//This method is a implementation of fmt.Stringer interface.
+func (c Code) String() string {
+	return string(c)
+}
+
+//NewCode function returns an error instance with error code and context informations.
+//Message of the error instance is default message of registered error code.
+//(If the code is not registered or has no default message, the code itself is used for the message.)
+func NewCode(code Code, opts ...ErrorContextFunc) error {
+	if len(code) == 0 {
+		return nil
+	}
+	msg := code.Info().Message
+	if len(msg) == 0 {
+		msg = string(code)
+	}
+	return newError(errors.New(msg), false, 2, append([]ErrorContextFunc{WithCode(code)}, opts...)...)
+}
+
+//WithCode function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that represents error code.
+func WithCode(code Code) ErrorContextFunc {
+	return func(e *Error) {
+		_ = e.SetCode(code)
+	}
+}
+
+//SetCode method sets error code.
+//SetCode method panics if the instance is frozen.
+func (e *Error) SetCode(code Code) *Error {
+	if e == nil {
+		return e
+	}
+	e.mustNotFrozen("SetCode")
+	e.Code = code
+	return e
+}
+
+//CodeOf function finds the first error code in err's tree.
+//CodeOf function returns empty Code if err's tree has no error code.
+func CodeOf(err error) Code {
+	var code Code
+	walk(err, func(err error) bool {
+		if e, ok := err.(*Error); ok && e != nil && len(e.Code) > 0 {
+			code = e.Code
+			return false
+		}
+		return true
+	})
+	return code
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
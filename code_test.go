@@ -0,0 +1,141 @@
+package errs
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+var (
+	codeTest        = RegisterCode("TEST-001", "error for test", SeverityError, "https://example.com/errors/TEST-001")
+	codeTestNoMsg   = RegisterCode("TEST-002", "", SeverityWarning, "")
+	codeNotRegister = Code("TEST-999")
+)
+
+func TestRegisterCode(t *testing.T) {
+	testCases := []struct {
+		code Code
+	}{
+		{code: ""},
+		{code: codeTest},
+	}
+
+	for _, tc := range testCases {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("RegisterCode(%q) does not panic", tc.code)
+				}
+			}()
+			_ = RegisterCode(tc.code, "duplicated", SeverityInfo, "")
+		}()
+	}
+}
+
+func TestLookupCode(t *testing.T) {
+	testCases := []struct {
+		code Code
+		ok   bool
+		info CodeInfo
+	}{
+		{code: codeTest, ok: true, info: CodeInfo{Code: codeTest, Message: "error for test", Severity: SeverityError, URL: "https://example.com/errors/TEST-001"}},
+		{code: codeTestNoMsg, ok: true, info: CodeInfo{Code: codeTestNoMsg, Severity: SeverityWarning}},
+		{code: codeNotRegister, ok: false, info: CodeInfo{}},
+	}
+
+	for _, tc := range testCases {
+		info, ok := LookupCode(tc.code)
+		if ok != tc.ok {
+			t.Errorf("LookupCode(%q) is %v, want %v", tc.code, ok, tc.ok)
+		}
+		if info != tc.info {
+			t.Errorf("LookupCode(%q) is %+v, want %+v", tc.code, info, tc.info)
+		}
+		if info := tc.code.Info(); info.Code != tc.code {
+			t.Errorf("Code(%q).Info().Code is %q, want %q", tc.code, info.Code, tc.code)
+		}
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	testCases := []struct {
+		severity Severity
+		str      string
+	}{
+		{severity: SeverityUnknown, str: "unknown"},
+		{severity: SeverityInfo, str: "info"},
+		{severity: SeverityWarning, str: "warning"},
+		{severity: SeverityError, str: "error"},
+		{severity: SeverityCritical, str: "critical"},
+		{severity: Severity(100), str: "Severity(100)"},
+	}
+
+	for _, tc := range testCases {
+		if str := tc.severity.String(); str != tc.str {
+			t.Errorf("Severity(%d) is %v, want %v", int(tc.severity), str, tc.str)
+		}
+	}
+}
+
+func TestNewCode(t *testing.T) {
+	testCases := []struct {
+		code Code
+		msg  string
+		json string
+	}{
+		{code: codeTest, msg: "error for test", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"error for test"},"Code":"TEST-001","Context":{"function":"github.com/spiegel-im-spiegel/errs.TestNewCode"}}`},
+		{code: codeTestNoMsg, msg: "TEST-002", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"TEST-002"},"Code":"TEST-002","Context":{"function":"github.com/spiegel-im-spiegel/errs.TestNewCode"}}`},
+		{code: codeNotRegister, msg: "TEST-999", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"TEST-999"},"Code":"TEST-999","Context":{"function":"github.com/spiegel-im-spiegel/errs.TestNewCode"}}`},
+	}
+
+	for _, tc := range testCases {
+		err := NewCode(tc.code)
+		if str := err.Error(); str != tc.msg {
+			t.Errorf("NewCode(%q) is %v, want %v", tc.code, str, tc.msg)
+		}
+		if str := trimStackTrace(fmt.Sprintf("%+v", err)); str != tc.json {
+			t.Errorf("NewCode(%q) is %v, want %v", tc.code, str, tc.json)
+		}
+	}
+	if err := NewCode(""); err != nil {
+		t.Errorf("NewCode(\"\") is \"%v\", want <nil>", err)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code Code
+	}{
+		{err: nil, code: ""},
+		{err: os.ErrInvalid, code: ""},
+		{err: New("error"), code: ""},
+		{err: New("error", WithCode(codeTest)), code: codeTest},
+		{err: Wrap(New("error", WithCode(codeTest))), code: codeTest},
+		{err: Wrap(New("error", WithCode(codeTest)), WithCode(codeTestNoMsg)), code: codeTestNoMsg},
+		{err: New("error", WithCause(NewCode(codeTest))), code: codeTest},
+		{err: fmt.Errorf("error: %w", NewCode(codeTest)), code: codeTest},
+		{err: Join(os.ErrInvalid, NewCode(codeTest)), code: codeTest},
+	}
+
+	for _, tc := range testCases {
+		if code := CodeOf(tc.err); code != tc.code {
+			t.Errorf("CodeOf(\"%v\") is %q, want %q", tc.err, code, tc.code)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,350 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+const (
+	errorTypeName = "*errs.Error"
+)
+
+//jsonError type is intermediate data for decoding JSON data generated by EncodeJSON function. (internal)
+type jsonError struct {
+	Type          string
+	Msg           string
+	Err           json.RawMessage
+	Template      string
+	Code          Code
+	Kind          string
+	MessageID     string
+	MessageArgs   json.RawMessage
+	PublicMessage string
+	Context       json.RawMessage
+	Cause         json.RawMessage
+	Causes        []json.RawMessage
+	StackTrace    StackTrace
+}
+
+//decodedError type is error instance rebuilt from JSON data of other than Error type. (internal)
+//This type keeps the type name and message of original error instance.
+type decodedError struct {
+	typeName string
+	msg      string
+	cause    error
+}
+
+var _ error = (*decodedError)(nil)          //decodedError type is compatible with error interface
+var _ json.Marshaler = (*decodedError)(nil) //decodedError type is compatible with json.Marshaler interface
+
+//Error method returns error message of original error instance.
+func (e *decodedError) Error() string {
+	if e == nil {
+		return nilAngleString
+	}
+	return e.msg
+}
+
+//Unwrap method returns cause error in decodedError instance.
+func (e *decodedError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.cause
+}
+
+//MarshalJSON method returns serialize string with the type name of original error instance.
+func (e *decodedError) MarshalJSON() ([]byte, error) {
+	return []byte(encodeToString(e)), nil
+}
+
+//jsonTypeName method returns the type name of original error instance. (internal)
+func (e *decodedError) jsonTypeName() (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	return e.typeName, true
+}
+
+//decodedJoinError type is error instance rebuilt from JSON data of error with multiple cause errors. (internal)
+type decodedJoinError struct {
+	typeName string
+	msg      string
+	causes   []error
+}
+
+var _ error = (*decodedJoinError)(nil)          //decodedJoinError type is compatible with error interface
+var _ json.Marshaler = (*decodedJoinError)(nil) //decodedJoinError type is compatible with json.Marshaler interface
+
+//Error method returns error message of original error instance.
+func (e *decodedJoinError) Error() string {
+	if e == nil {
+		return nilAngleString
+	}
+	return e.msg
+}
+
+//Unwrap method returns cause errors in decodedJoinError instance.
+func (e *decodedJoinError) Unwrap() []error {
+	if e == nil {
+		return nil
+	}
+	return e.causes
+}
+
+//MarshalJSON method returns serialize string with the type name of original error instance.
+func (e *decodedJoinError) MarshalJSON() ([]byte, error) {
+	return []byte(encodeToString(e)), nil
+}
+
+//jsonTypeName method returns the type name of original error instance. (internal)
+func (e *decodedJoinError) jsonTypeName() (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	return e.typeName, true
+}
+
+//DecodeJSON function rebuilds error instance from JSON data generated by EncodeJSON function.
+//Error instances of other than Error type are rebuilt as error instances that keep original type names and messages.
+//Numbers in Context are rebuilt as int64 or float64 values (or values of the types declared by NewKey function).
+func DecodeJSON(b []byte) (error, error) {
+	return decodeJSON(b)
+}
+
+//UnmarshalJSON method rebuilds Error instance from JSON data generated by EncodeJSON function.
+//This method is implementation of json.Unmarshaler interface.
+//UnmarshalJSON method returns error if the instance is frozen.
+func (e *Error) UnmarshalJSON(b []byte) error {
+	if e == nil {
+		return &json.InvalidUnmarshalError{Type: nil}
+	}
+	if e.Frozen() {
+		return fmt.Errorf("cannot unmarshal into frozen %s instance", errorTypeName)
+	}
+	if isNullJSON(b) {
+		return nil
+	}
+	je := &jsonError{}
+	if err := json.Unmarshal(b, je); err != nil {
+		return err
+	}
+	if len(je.Type) > 0 && je.Type != errorTypeName {
+		//wrap other error type
+		err, decErr := decodeJSON(b)
+		if decErr != nil {
+			return decErr
+		}
+		*e = Error{Err: err, wrapFlag: true}
+		return nil
+	}
+	return e.decode(je)
+}
+
+//decode method sets data of jsonError instance. (internal)
+func (e *Error) decode(je *jsonError) error {
+	err, decErr := decodeJSON(je.Err)
+	if decErr != nil {
+		return decErr
+	}
+	if err == nil {
+		return fmt.Errorf("no \"Err\" element in %s data", errorTypeName)
+	}
+	if len(je.Template) > 0 {
+		err = &messageTemplate{tmpl: je.Template}
+	}
+	cause, decErr := decodeJSON(je.Cause)
+	if decErr != nil {
+		return decErr
+	}
+	context, decErr := decodeContext(je.Context)
+	if decErr != nil {
+		return decErr
+	}
+	args, decErr := decodeArgs(je.MessageArgs)
+	if decErr != nil {
+		return decErr
+	}
+	*e = Error{Err: err, Cause: cause, Code: je.Code, Kind: decodeKind(je.Kind), MessageID: je.MessageID, MessageArgs: args, PublicMessage: je.PublicMessage, Context: context, wrapFlag: len(je.Template) == 0, stack: newStack(je.StackTrace)}
+	return nil
+}
+
+//decodeArgs returns arguments of message.
+//Numbers are decoded as int if possible (for %d verb), otherwise float64. (internal)
+func decodeArgs(b json.RawMessage) ([]interface{}, error) {
+	if isNullJSON(b) {
+		return nil, nil
+	}
+	var args []interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&args); err != nil {
+		return nil, err
+	}
+	for i, arg := range args {
+		if n, ok := arg.(json.Number); ok {
+			if v, err := strconv.Atoi(n.String()); err == nil {
+				args[i] = v
+			} else if v, err := n.Float64(); err == nil {
+				args[i] = v
+			}
+		}
+	}
+	return args, nil
+}
+
+//decodeContext returns context information.
+//Values of keys declared by NewKey function are converted to the declared types if possible.
+//Other numbers are decoded as int64 if possible, otherwise float64. (internal)
+func decodeContext(b json.RawMessage) (map[string]interface{}, error) {
+	if isNullJSON(b) {
+		return nil, nil
+	}
+	var context map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&context); err != nil {
+		return nil, err
+	}
+	for name, value := range context {
+		if n, ok := value.(json.Number); ok {
+			if typ, ok := keyType(name); ok {
+				if v, ok := convertNumber(n, typ); ok {
+					context[name] = v
+					continue
+				}
+			}
+		}
+		context[name] = normalizeNumbers(value)
+	}
+	return context, nil
+}
+
+//normalizeNumbers replaces json.Number values (including in maps and slices) with int64 or float64 values. (internal)
+func normalizeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for k, elm := range v {
+			v[k] = normalizeNumbers(elm)
+		}
+	case []interface{}:
+		for i, elm := range v {
+			v[i] = normalizeNumbers(elm)
+		}
+	}
+	return value
+}
+
+//convertNumber returns number converted to numeric type typ. (internal)
+func convertNumber(n json.Number, typ reflect.Type) (interface{}, bool) {
+	v := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(n.String(), 10, 64)
+		if err != nil || v.OverflowInt(i) {
+			return nil, false
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(n.String(), 10, 64)
+		if err != nil || v.OverflowUint(u) {
+			return nil, false
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := n.Float64()
+		if err != nil || v.OverflowFloat(f) {
+			return nil, false
+		}
+		v.SetFloat(f)
+	default:
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+//decodeKind returns kind declared by NewKind function.
+//If the kind is not declared, decodeKind returns new undeclared kind with the name.
+//Undeclared kinds are not registered, and match kinds of the same name by errors.Is function. (internal)
+func decodeKind(name string) *Kind {
+	if len(name) == 0 {
+		return nil
+	}
+	if k, ok := LookupKind(name); ok {
+		return k
+	}
+	return &Kind{name: name, decoded: true}
+}
+
+//decodeJSON returns rebuilt error instance. (internal)
+func decodeJSON(b []byte) (error, error) {
+	if isNullJSON(b) {
+		return nil, nil
+	}
+	je := &jsonError{}
+	if err := json.Unmarshal(b, je); err != nil {
+		return nil, err
+	}
+	if je.Type == errorTypeName {
+		e := &Error{}
+		if err := e.decode(je); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if len(je.Causes) > 0 {
+		causes := make([]error, 0, len(je.Causes))
+		for _, cb := range je.Causes {
+			cause, err := decodeJSON(cb)
+			if err != nil {
+				return nil, err
+			}
+			if cause != nil {
+				causes = append(causes, cause)
+			}
+		}
+		return &decodedJoinError{typeName: je.Type, msg: je.Msg, causes: causes}, nil
+	}
+	//Cause element or Err element (for custom json.Marshaler)
+	cb := je.Cause
+	if isNullJSON(cb) {
+		cb = je.Err
+	}
+	cause, err := decodeJSON(cb)
+	if err != nil {
+		return nil, err
+	}
+	return &decodedError{typeName: je.Type, msg: je.Msg, cause: cause}, nil
+}
+
+//isNullJSON reports whether JSON data is empty or null. (internal)
+func isNullJSON(b []byte) bool {
+	b = bytes.TrimSpace(b)
+	return len(b) == 0 || bytes.Equal(b, []byte("null"))
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
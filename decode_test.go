@@ -0,0 +1,172 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	_, pathErr := os.Open("not-exist.txt")
+	testCases := []struct {
+		err       error
+		roundTrip bool
+	}{
+		{err: nil, roundTrip: true},
+		{err: os.ErrInvalid, roundTrip: true},
+		{err: pathErr, roundTrip: true},
+		{err: errTest, roundTrip: true},
+		{err: wrapedErrTest, roundTrip: true},
+		{err: New("wrapped message", WithCause(pathErr), WithContext("foo", "bar"), WithContext("num", 1)), roundTrip: true},
+		{err: Wrap(errors.New("wrapped message"), WithCause(errTest), WithContext("<html>", "&")), roundTrip: true},
+		{err: Wrap(pathErr, WithContext("path", "not-exist.txt")), roundTrip: true},
+		{err: New("wrapped message", WithCause(wrapedErrTest2)), roundTrip: false},
+		{err: Join(pathErr, errTest), roundTrip: true},
+		{err: Wrap(pathErr, WithCode(codeTest)), roundTrip: true},
+		{err: New("wrapped message", WithCauses(os.ErrInvalid, wrapedErrTest)), roundTrip: true},
+	}
+
+	for _, tc := range testCases {
+		src := EncodeJSON(tc.err)
+		err, e := DecodeJSON([]byte(src))
+		if e != nil {
+			t.Errorf("DecodeJSON(%v) is \"%v\", want <nil>", src, e)
+			continue
+		}
+		if tc.err == nil {
+			if err != nil {
+				t.Errorf("DecodeJSON(%v) is \"%v\", want <nil>", src, err)
+			}
+			continue
+		}
+		if err.Error() != tc.err.Error() {
+			t.Errorf("DecodeJSON(%v).Error() is \"%v\", want \"%v\"", src, err.Error(), tc.err.Error())
+		}
+		if c, want := Cause(err).Error(), Cause(tc.err).Error(); c != want {
+			t.Errorf("Cause(DecodeJSON(%v)) is \"%v\", want \"%v\"", src, c, want)
+		}
+		if str := EncodeJSON(err); tc.roundTrip && str != src {
+			t.Errorf("EncodeJSON(DecodeJSON(%v)) is %v, want %v", src, str, src)
+		}
+	}
+}
+
+func TestDecodeJSONContext(t *testing.T) {
+	keyRatio := NewKey[float32]("test.ratio")
+	src := EncodeJSON(New("decode context", WithValue(keyCount, 123), WithValue(keyRatio, 0.5), WithContext("num", 456), WithContext("float", 1.5), WithContext("list", []int{1, 2}), WithoutCaller()))
+	err, e := DecodeJSON([]byte(src))
+	if e != nil {
+		t.Fatalf("DecodeJSON(%v) is \"%v\", want <nil>", src, e)
+	}
+	if v, ok := Value(err, keyCount); !ok || v != 123 {
+		t.Errorf("Value(%v) is %v (%v), want %v", keyCount, v, ok, 123)
+	}
+	if v, ok := Value(err, keyRatio); !ok || v != 0.5 {
+		t.Errorf("Value(%v) is %v (%v), want %v", keyRatio, v, ok, 0.5)
+	}
+	fields := Fields(err)
+	testCases := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "num", value: int64(456)},
+		{name: "float", value: 1.5},
+		{name: "list", value: []interface{}{int64(1), int64(2)}},
+	}
+	for _, tc := range testCases {
+		if v := fields[tc.name]; !reflect.DeepEqual(v, tc.value) {
+			t.Errorf("Fields()[%q] is %#v, want %#v", tc.name, v, tc.value)
+		}
+	}
+}
+
+func TestDecodeJSONKind(t *testing.T) {
+	src := `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"decode kind"},"Kind":"errs.test.Undeclared"}`
+	err1, e := DecodeJSON([]byte(src))
+	if e != nil {
+		t.Fatalf("DecodeJSON(%v) is \"%v\", want <nil>", src, e)
+	}
+	err2, e := DecodeJSON([]byte(src))
+	if e != nil {
+		t.Fatalf("DecodeJSON(%v) is \"%v\", want <nil>", src, e)
+	}
+	if k1, k2 := KindOf(err1), KindOf(err2); k1 == k2 || k1.Name() != k2.Name() {
+		t.Errorf("KindOf() is %p and %p, want different instances of the same name", k1, k2)
+	}
+	if !Is(err2, KindOf(err1)) {
+		t.Errorf("Is(%v, %v) is false, want true", err2, KindOf(err1))
+	}
+	if _, ok := LookupKind("errs.test.Undeclared"); ok {
+		t.Errorf("LookupKind(%q) is true, want false", "errs.test.Undeclared")
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		err     error
+		msg     string
+		cause   string
+		context map[string]interface{}
+	}{
+		{err: New("error", WithContext("foo", "bar")), msg: "error", cause: "error", context: map[string]interface{}{"foo": "bar", "function": "github.com/spiegel-im-spiegel/errs.TestUnmarshalJSON"}},
+		{err: New("wrapped error", WithCause(os.ErrInvalid)), msg: "wrapped error: invalid argument", cause: "invalid argument", context: map[string]interface{}{"function": "github.com/spiegel-im-spiegel/errs.TestUnmarshalJSON"}},
+		{err: os.ErrInvalid, msg: "invalid argument", cause: "invalid argument", context: nil},
+	}
+
+	for _, tc := range testCases {
+		b := []byte(EncodeJSON(tc.err))
+		err := &Error{}
+		if e := json.Unmarshal(b, err); e != nil {
+			t.Errorf("json.Unmarshal(%s) is %v, want <nil>", b, e)
+			continue
+		}
+		if err.Error() != tc.msg {
+			t.Errorf("json.Unmarshal(%s).Error() is \"%v\", want \"%v\"", b, err.Error(), tc.msg)
+		}
+		if c := Cause(err).Error(); c != tc.cause {
+			t.Errorf("Cause(json.Unmarshal(%s)) is \"%v\", want \"%v\"", b, c, tc.cause)
+		}
+		if len(err.Context) != len(tc.context) {
+			t.Errorf("json.Unmarshal(%s).Context is %v, want %v", b, err.Context, tc.context)
+		}
+		for k, v := range tc.context {
+			if err.Context[k] != v {
+				t.Errorf("json.Unmarshal(%s).Context[%q] is %v, want %v", b, k, err.Context[k], v)
+			}
+		}
+	}
+}
+
+func TestDecodeJSONError(t *testing.T) {
+	testCases := []struct {
+		src string
+	}{
+		{src: `{`},
+		{src: `[]`},
+		{src: `{"Type":"*errs.Error"}`},
+		{src: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"error"},"Context":[]}`},
+	}
+
+	for _, tc := range testCases {
+		if _, err := DecodeJSON([]byte(tc.src)); err == nil {
+			t.Errorf("DecodeJSON(%v) is <nil>, want error", tc.src)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
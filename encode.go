@@ -0,0 +1,406 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+//EncodeOption type is functional option for EncodeJSON function and Encoder.
+type EncodeOption func(*encodeOptions)
+
+//encodeOptions type is options of EncodeJSON function. (internal)
+type encodeOptions struct {
+	fields bool
+}
+
+//newEncodeOptions returns encodeOptions instance. (internal)
+func newEncodeOptions(opts ...EncodeOption) encodeOptions {
+	o := encodeOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+//Encoder type writes JSON data of error instances to an output stream.
+//The JSON data is the same as EncodeJSON function, but Encoder writes it with pooled buffers and no intermediate strings.
+type Encoder struct {
+	w    io.Writer
+	opts encodeOptions
+}
+
+//NewEncoder function returns a new Encoder instance that writes to w.
+func NewEncoder(w io.Writer, opts ...EncodeOption) *Encoder {
+	return &Encoder{w: w, opts: newEncodeOptions(opts...)}
+}
+
+//Encode method writes JSON data of err to the stream, followed by a newline character (like json.Encoder).
+func (enc *Encoder) Encode(err error) error {
+	s := newEncodeState()
+	defer s.free()
+	s.encodeTop(err, enc.opts)
+	s.buf.WriteByte('\n')
+	_, werr := enc.w.Write(s.buf.Bytes())
+	return werr
+}
+
+//encodeState type is working state of JSON encoding. (internal)
+type encodeState struct {
+	buf     bytes.Buffer
+	policy  *RedactionPolicy
+	scratch []byte
+	keys    []string
+	guard   guard
+}
+
+var encodeStatePool = sync.Pool{
+	New: func() interface{} { return &encodeState{} },
+}
+
+//maxPooledBufferSize is the maximum capacity of buffer returned to pool. (internal)
+const maxPooledBufferSize = 64 * 1024
+
+//newEncodeState returns encodeState instance from pool. (internal)
+func newEncodeState() *encodeState {
+	s := encodeStatePool.Get().(*encodeState)
+	s.buf.Reset()
+	s.policy = redactionPolicy.Load()
+	s.guard = guard{path: s.guard.path[:0], max: MaxDepth()}
+	return s
+}
+
+//free method returns encodeState instance to pool. (internal)
+func (s *encodeState) free() {
+	if s.buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	s.policy = nil
+	for i := range s.keys {
+		s.keys[i] = ""
+	}
+	s.keys = s.keys[:0]
+	for i := range s.guard.path {
+		s.guard.path[i] = nil
+	}
+	s.guard.path = s.guard.path[:0]
+	encodeStatePool.Put(s)
+}
+
+//encodeToString returns JSON data of err as string. (internal)
+func encodeToString(err error, opts ...EncodeOption) string {
+	s := newEncodeState()
+	defer s.free()
+	s.encodeTop(err, newEncodeOptions(opts...))
+	return s.buf.String()
+}
+
+//encodeTop method writes JSON data of top level error instance. (internal)
+func (s *encodeState) encodeTop(err error, opts encodeOptions) {
+	s.encode(err, false)
+	if opts.fields {
+		s.encodeFields(err)
+	}
+}
+
+//encode method writes JSON data of error instance.
+//If html is true, strings are escaped for HTML (for compatibility with json.HTMLEscape in Err element).
+//Cyclic error is written as {"Type":"errs.cycle"}, and error deeper than MaxDepth is written as {"Truncated":true}. (internal)
+func (s *encodeState) encode(err error, html bool) {
+	switch s.guard.enter(err) {
+	case guardCycle:
+		s.buf.WriteString(`{"Type":"` + cycleTypeName + `"}`)
+		return
+	case guardTruncated:
+		s.buf.WriteString(`{"Truncated":true}`)
+		return
+	}
+	defer s.guard.leave()
+	switch e := err.(type) {
+	case *Error:
+		s.encodeError(e, html)
+		return
+	case jsonTypeNamer:
+		if name, ok := e.jsonTypeName(); ok {
+			//the same as json.Marshal with MarshalJSON method (escaped for HTML)
+			s.encodeGeneric(name, err, true)
+		} else {
+			s.buf.WriteString("null")
+		}
+		return
+	case json.Marshaler:
+		if b, ee := json.Marshal(e); ee == nil {
+			s.buf.Write(bytes.TrimSpace(b))
+			return
+		}
+	}
+	if err == nil {
+		s.buf.WriteString("null")
+		return
+	}
+	s.encodeGeneric(reflect.TypeOf(err).String(), err, html)
+}
+
+//encodeError method writes JSON data of Error instance. (internal)
+func (s *encodeState) encodeError(e *Error, html bool) {
+	if e == nil {
+		s.buf.WriteString("null")
+		return
+	}
+	s.buf.WriteString(`{"Type":`)
+	s.writeQuoted(errorTypeName, html)
+	s.buf.WriteString(`,"Err":`)
+	if t, ok := e.Err.(*messageTemplate); ok && t != nil {
+		s.buf.WriteString(`{"Type":`)
+		s.writeQuoted(templateTypeName, true)
+		s.buf.WriteString(`,"Msg":`)
+		s.writeQuoted(s.policy.redactString(t.render(e.Context, s.policy)), true)
+		s.buf.WriteString(`},"Template":`)
+		s.writeString(t.tmpl)
+	} else {
+		s.encode(e.Err, true)
+	}
+	if len(e.Code) > 0 {
+		s.buf.WriteString(`,"Code":`)
+		s.writeString(string(e.Code))
+	}
+	if e.Kind != nil {
+		s.buf.WriteString(`,"Kind":`)
+		s.writeString(e.Kind.Name())
+	}
+	if len(e.MessageID) > 0 {
+		s.buf.WriteString(`,"MessageID":`)
+		s.writeString(e.MessageID)
+		if len(e.MessageArgs) > 0 {
+			s.encodeArgs(e.MessageArgs)
+		}
+	}
+	if len(e.PublicMessage) > 0 {
+		s.buf.WriteString(`,"PublicMessage":`)
+		s.writeString(s.policy.redactString(e.PublicMessage))
+	}
+	if len(e.Context) > 0 {
+		s.encodeMap("Context", e.Context)
+	}
+	if e.Cause != nil && !reflect.ValueOf(e.Cause).IsZero() {
+		s.buf.WriteString(`,"Cause":`)
+		s.encode(e.Cause, html)
+	}
+	if st := e.stack.symbolize(); len(st) > 0 {
+		s.buf.WriteString(`,"StackTrace":[`)
+		for i, f := range st {
+			if i > 0 {
+				s.buf.WriteByte(',')
+			}
+			s.buf.WriteString(`{"Function":`)
+			s.writeString(f.Function)
+			s.buf.WriteString(`,"File":`)
+			s.writeString(f.File)
+			s.buf.WriteString(`,"Line":`)
+			s.writeInt(int64(f.Line))
+			s.buf.WriteByte('}')
+		}
+		s.buf.WriteByte(']')
+	}
+	s.buf.WriteByte('}')
+}
+
+//encodeGeneric method writes JSON data of error instance other than Error type. (internal)
+func (s *encodeState) encodeGeneric(typeName string, err error, html bool) {
+	s.buf.WriteString(`{"Type":`)
+	s.writeQuoted(typeName, html)
+	s.buf.WriteString(`,"Msg":`)
+	s.writeQuoted(s.policy.redactString(errorMessage(err)), true)
+	first := true
+	for _, b := range branchesOf(err) {
+		switch {
+		case b.name != "causes":
+			s.buf.WriteString(`,"Cause":`)
+		case first:
+			s.buf.WriteString(`,"Causes":[`)
+			first = false
+		default:
+			s.buf.WriteByte(',')
+		}
+		s.encode(b.err, html)
+	}
+	if !first {
+		s.buf.WriteByte(']')
+	}
+	s.buf.WriteByte('}')
+}
+
+//encodeFields method adds "Fields" element to the top level of JSON object. (internal)
+func (s *encodeState) encodeFields(err error) {
+	b := s.buf.Bytes()
+	if len(b) < 2 || b[0] != '{' || b[len(b)-1] != '}' {
+		return
+	}
+	fields := Fields(err)
+	if len(fields) == 0 {
+		return
+	}
+	mark := s.buf.Len()
+	s.buf.Truncate(mark - 1)
+	if !s.encodeMap("Fields", fields) {
+		s.buf.Truncate(mark - 1)
+		s.buf.WriteByte('}')
+		return
+	}
+	s.buf.WriteByte('}')
+	if mark == 2 {
+		//remove leading comma of empty object
+		b := s.buf.Bytes()
+		copy(b[1:], b[2:])
+		s.buf.Truncate(s.buf.Len() - 1)
+	}
+}
+
+//encodeMap method writes context map (redacted) as JSON element with sorted keys.
+//If a value cannot be marshaled, encodeMap method writes nothing and returns false. (internal)
+func (s *encodeState) encodeMap(name string, m map[string]interface{}) bool {
+	mark := s.buf.Len()
+	keys := s.keys[:0]
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	s.keys = keys
+	s.buf.WriteString(`,"`)
+	s.buf.WriteString(name)
+	s.buf.WriteString(`":{`)
+	for i, k := range keys {
+		if i > 0 {
+			s.buf.WriteByte(',')
+		}
+		s.writeString(k)
+		s.buf.WriteByte(':')
+		if !s.writeValue(s.policy.redactValue(k, m[k])) {
+			s.buf.Truncate(mark)
+			return false
+		}
+	}
+	s.buf.WriteByte('}')
+	return true
+}
+
+//encodeArgs method writes arguments of message (redacted) as "MessageArgs" element.
+//If an argument cannot be marshaled, encodeArgs method writes nothing. (internal)
+func (s *encodeState) encodeArgs(args []interface{}) {
+	mark := s.buf.Len()
+	s.buf.WriteString(`,"MessageArgs":[`)
+	for i, arg := range args {
+		if i > 0 {
+			s.buf.WriteByte(',')
+		}
+		if !s.writeValue(s.policy.redactValue("", arg)) {
+			s.buf.Truncate(mark)
+			return
+		}
+	}
+	s.buf.WriteByte(']')
+}
+
+//writeValue method writes JSON value of context. (internal)
+func (s *encodeState) writeValue(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		s.buf.WriteString("null")
+	case string:
+		s.writeString(x)
+	case bool:
+		if x {
+			s.buf.WriteString("true")
+		} else {
+			s.buf.WriteString("false")
+		}
+	case int:
+		s.writeInt(int64(x))
+	case int8:
+		s.writeInt(int64(x))
+	case int16:
+		s.writeInt(int64(x))
+	case int32:
+		s.writeInt(int64(x))
+	case int64:
+		s.writeInt(x)
+	case uint:
+		s.writeUint(uint64(x))
+	case uint8:
+		s.writeUint(uint64(x))
+	case uint16:
+		s.writeUint(uint64(x))
+	case uint32:
+		s.writeUint(uint64(x))
+	case uint64:
+		s.writeUint(x)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return false
+		}
+		s.buf.Write(b)
+	}
+	return true
+}
+
+//writeInt method writes JSON number of signed integer. (internal)
+func (s *encodeState) writeInt(i int64) {
+	s.scratch = strconv.AppendInt(s.scratch[:0], i, 10)
+	s.buf.Write(s.scratch)
+}
+
+//writeUint method writes JSON number of unsigned integer. (internal)
+func (s *encodeState) writeUint(u uint64) {
+	s.scratch = strconv.AppendUint(s.scratch[:0], u, 10)
+	s.buf.Write(s.scratch)
+}
+
+//writeString method writes JSON string (the same as json.Marshal). (internal)
+func (s *encodeState) writeString(str string) {
+	for i := 0; i < len(str); i++ {
+		if c := str[i]; c < 0x20 || c >= utf8.RuneSelf || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			b, _ := json.Marshal(str)
+			s.buf.Write(b)
+			return
+		}
+	}
+	s.buf.WriteByte('"')
+	s.buf.WriteString(str)
+	s.buf.WriteByte('"')
+}
+
+//writeQuoted method writes string quoted by strconv.Quote function (the same as %q verb). (internal)
+func (s *encodeState) writeQuoted(str string, html bool) {
+	s.scratch = strconv.AppendQuote(s.scratch[:0], str)
+	if html && bytes.ContainsAny(s.scratch, "<>&  ") {
+		json.HTMLEscape(&s.buf, s.scratch)
+		return
+	}
+	s.buf.Write(s.scratch)
+}
+
+//jsonTypeNamer interface is implemented by error types rebuilt from JSON data. (internal)
+type jsonTypeNamer interface {
+	jsonTypeName() (string, bool)
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
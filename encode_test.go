@@ -0,0 +1,153 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"syscall"
+	"testing"
+)
+
+type marshalerError struct {
+	Msg string
+}
+
+func (e *marshalerError) Error() string { return e.Msg }
+func (e *marshalerError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"message": e.Msg})
+}
+
+func encodeTestCases() []error {
+	decoded, _ := DecodeJSON([]byte(`{"Type":"*errs.Error","Err":{"Type":"*fs.PathError","Msg":"open <file>: not exist","Cause":{"Type":"syscall.Errno","Msg":"no such file"}},"Cause":{"Type":"*errors.joinError","Msg":"a\nb","Causes":[{"Type":"*errors.errorString","Msg":"a"},{"Type":"*errors.errorString","Msg":"b"}]},"StackTrace":[{"Function":"main.main","File":"/path/to/main.go","Line":12}]}`))
+	_, pathErr := os.Open("not-exist.txt")
+	return []error{
+		nil,
+		(*Error)(nil),
+		os.ErrInvalid,
+		pathErr,
+		New("simple error"),
+		New("html <tag> & \"quote\"   \t \x01 日本語", WithContext("html", "<a href=\"x\">&</a>"), WithContext("日本語", "値 ")),
+		Wrap(pathErr, WithContext("path", "not-exist.txt"), WithCode("TEST-001")),
+		New("wrapper", WithCause(Wrap(&testError{Msg: "<inner>", Err: syscall.ENOENT}))),
+		Wrap(New("<inner & error>", WithContext("key", "<value>")), WithCause(os.ErrInvalid)),
+		Join(os.ErrInvalid, nil, New("joined", WithContext("n", 1))),
+		New("causes", WithCauses(os.ErrInvalid, fmt.Errorf("wrapped <%w>", os.ErrNotExist))),
+		New("context values", WithContext("int", 1), WithContext("int8", int8(-8)), WithContext("uint64", uint64(64)), WithContext("float", 1.5e21),
+			WithContext("bool", true), WithContext("nil", nil), WithContext("slice", []int{1, 2}), WithContext("map", map[string]int{"a": 1}),
+			WithContext("number", json.Number("12.5")), WithContext("error", os.ErrInvalid), WithContext("struct", struct{ A string }{A: "<a>"})),
+		New("unsupported context", WithContext("chan", make(chan int)), WithContext("foo", "bar")),
+		New("secret", WithSecret("token", "secret-token"), WithContext("password", "p@ssw0rd")),
+		Wrap(&marshalerError{Msg: "marshaler <error>"}),
+		&marshalerError{Msg: "marshaler"},
+		decoded,
+		Wrap(decoded, WithContext("foo", "bar")),
+	}
+}
+
+func TestEncodeJSONCompatibility(t *testing.T) {
+	SetRedactionPolicy(&RedactionPolicy{
+		Keys:   []*regexp.Regexp{regexp.MustCompile(`(?i)password`)},
+		Values: []*regexp.Regexp{regexp.MustCompile(`secret`)},
+	})
+	t.Cleanup(func() { SetRedactionPolicy(nil) })
+
+	for _, err := range encodeTestCases() {
+		want := legacyEncodeJSON(err)
+		if str := EncodeJSON(err); str != want {
+			t.Errorf("EncodeJSON(\"%v\") is %v, want %v", err, str, want)
+		}
+		buf := &bytes.Buffer{}
+		if e := NewEncoder(buf).Encode(err); e != nil {
+			t.Errorf("Encoder.Encode(\"%v\") is \"%v\", want <nil>", err, e)
+		}
+		if str := buf.String(); str != want+"\n" {
+			t.Errorf("Encoder.Encode(\"%v\") is %v, want %v", err, str, want)
+		}
+		if e, ok := err.(*Error); ok && e != nil {
+			if str := fmt.Sprintf("%+v", e); str != want {
+				t.Errorf("%%+v of \"%v\" is %v, want %v", err, str, want)
+			}
+		}
+	}
+}
+
+func TestEncoderWithMergedFields(t *testing.T) {
+	err := Wrap(New("inner", WithContext("path", "inner.txt")), WithContext("path", "outer.txt"))
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf, WithMergedFields())
+	for i := 0; i < 2; i++ {
+		if e := enc.Encode(err); e != nil {
+			t.Errorf("Encoder.Encode(\"%v\") is \"%v\", want <nil>", err, e)
+		}
+	}
+	want := EncodeJSON(err, WithMergedFields()) + "\n"
+	if str := buf.String(); str != want+want {
+		t.Errorf("Encoder.Encode(\"%v\") is %v, want %v", err, str, want+want)
+	}
+	if str := EncodeJSON(&marshalerError{Msg: "error"}, WithMergedFields()); str != `{"message":"error"}` {
+		t.Errorf("EncodeJSON(marshalerError) is %v, want %v", str, `{"message":"error"}`)
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, io.ErrShortWrite }
+
+func TestEncoderWriteError(t *testing.T) {
+	if err := NewEncoder(errWriter{}).Encode(os.ErrInvalid); !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("Encoder.Encode() is \"%v\", want \"%v\"", err, io.ErrShortWrite)
+	}
+}
+
+func benchmarkError() error {
+	_, pathErr := os.Open("not-exist.txt")
+	return Wrap(
+		New("file open error", WithCause(pathErr), WithContext("path", "not-exist.txt"), WithContext("count", 3)),
+		WithContext("user", "alice"),
+		WithCode("TEST-001"),
+	)
+}
+
+func BenchmarkEncodeJSON(b *testing.B) {
+	err := benchmarkError()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = EncodeJSON(err)
+	}
+}
+
+func BenchmarkEncodeJSONLegacy(b *testing.B) {
+	err := benchmarkError()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = legacyEncodeJSON(err)
+	}
+}
+
+func BenchmarkEncoder(b *testing.B) {
+	err := benchmarkError()
+	enc := NewEncoder(io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = enc.Encode(err)
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
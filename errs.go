@@ -2,13 +2,11 @@
 package errs
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"reflect"
-	"runtime"
 	"strings"
+	"sync/atomic"
 )
 
 const (
@@ -19,16 +17,30 @@ const (
 //This type is for wrapping cause error instance.
 type Error struct {
 	wrapFlag bool
+	frozen   atomic.Bool
+	stack    *stack
+	capture  *captureOptions
 	Err      error
 	Cause    error
-	Context  map[string]interface{}
+	Code     Code
+	Kind     *Kind
+	//MessageID and MessageArgs are ID and arguments of localized message (see WithMessageID and Translate functions).
+	MessageID   string
+	MessageArgs []interface{}
+	//PublicMessage is user-facing message (see WithPublicMessage and PublicMessage functions).
+	PublicMessage string
+	Context       map[string]interface{}
 }
 
-var _ error = (*Error)(nil)          //Error type is compatible with error interface
-var _ fmt.Stringer = (*Error)(nil)   //Error type is compatible with fmt.Stringer interface
-var _ fmt.GoStringer = (*Error)(nil) //Error type is compatible with fmt.GoStringer interface
-var _ fmt.Formatter = (*Error)(nil)  //Error type is compatible with fmt.Formatter interface
-var _ json.Marshaler = (*Error)(nil) //Error type is compatible with json.Marshaler interface
+var _ error = (*Error)(nil)            //Error type is compatible with error interface
+var _ fmt.Stringer = (*Error)(nil)     //Error type is compatible with fmt.Stringer interface
+var _ fmt.GoStringer = (*Error)(nil)   //Error type is compatible with fmt.GoStringer interface
+var _ fmt.Formatter = (*Error)(nil)    //Error type is compatible with fmt.Formatter interface
+var _ json.Marshaler = (*Error)(nil)   //Error type is compatible with json.Marshaler interface
+var _ json.Unmarshaler = (*Error)(nil) //Error type is compatible with json.Unmarshaler interface
+
+//ErrCyclicCause is error recorded as cause instead of the cause error whose tree has the instance itself (see SetCause method).
+var ErrCyclicCause = errors.New("cyclic cause")
 
 //ErrorContextFunc type is self-referential function type for New and Wrap functions. (functional options pattern)
 type ErrorContextFunc func(*Error)
@@ -41,6 +53,17 @@ func New(msg string, opts ...ErrorContextFunc) error {
 	return newError(errors.New(msg), false, 2, opts...)
 }
 
+//Join function returns a wrapping error instance of multiple errors.
+//Error instance returned by Join function is compatible with errors.Join function.
+//Join function returns nil if all of errs are nil.
+func Join(errs ...error) error {
+	err := errors.Join(errs...)
+	if err == nil {
+		return nil
+	}
+	return newError(err, true, 2)
+}
+
 //Wrap function returns a wrapping error instance with context informations.
 func Wrap(err error, opts ...ErrorContextFunc) error {
 	if err == nil {
@@ -49,17 +72,102 @@ func Wrap(err error, opts ...ErrorContextFunc) error {
 	return newError(err, true, 2, opts...)
 }
 
+//WrapSkip function returns a wrapping error instance with context informations.
+//The argument skip is the number of stack frames to skip for caller information and stack trace;
+//WrapSkip(err, 1) records the caller of the function that calls WrapSkip function. (for helper functions)
+func WrapSkip(err error, skip int, opts ...ErrorContextFunc) error {
+	if err == nil {
+		return nil
+	}
+	return newError(err, true, 2, append([]ErrorContextFunc{WithSkip(skip)}, opts...)...)
+}
+
+//Newf function returns an error instance with formatted message and context informations.
+//Arguments of ErrorContextFunc type in args are options (the same as New function), and the other arguments are for format.
+//One or more %w verbs in format are honored as in fmt.Errorf function, so the errors are reachable by errors.Is and errors.As functions.
+func Newf(format string, args ...interface{}) error {
+	if len(format) == 0 {
+		return nil
+	}
+	err, opts := errorf(format, args)
+	return newError(err, isWrapping(err), 2, opts...)
+}
+
+//Wrapf function returns a wrapping error instance with formatted message and context informations.
+//The formatted message is prepended to the message of err, and err is the cause of the instance.
+//Arguments of ErrorContextFunc type in args are options (the same as Wrap function), and the other arguments are for format.
+//%w verbs in format are honored as in fmt.Errorf function.
+func Wrapf(err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	if len(format) == 0 {
+		return newError(err, true, 2, optionsOf(args)...)
+	}
+	msg, opts := errorf(format, args)
+	return newError(msg, isWrapping(msg), 2, append(opts, WithCause(err))...)
+}
+
+//errorf returns error by fmt.Errorf function and options in args. (internal)
+func errorf(format string, args []interface{}) (error, []ErrorContextFunc) {
+	fargs := make([]interface{}, 0, len(args))
+	opts := []ErrorContextFunc{}
+	for _, arg := range args {
+		if opt, ok := arg.(ErrorContextFunc); ok {
+			opts = append(opts, opt)
+		} else {
+			fargs = append(fargs, arg)
+		}
+	}
+	return fmt.Errorf(format, fargs...), opts
+}
+
+//optionsOf returns options in args. (internal)
+func optionsOf(args []interface{}) []ErrorContextFunc {
+	opts := []ErrorContextFunc{}
+	for _, arg := range args {
+		if opt, ok := arg.(ErrorContextFunc); ok {
+			opts = append(opts, opt)
+		}
+	}
+	return opts
+}
+
+//isWrapping reports whether err wraps other errors (created by fmt.Errorf function with %w verb). (internal)
+func isWrapping(err error) bool {
+	switch err.(type) {
+	case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+		return true
+	}
+	return false
+}
+
 //newError returns error instance. (internal)
 func newError(err error, wrapFlag bool, depth int, opts ...ErrorContextFunc) error {
-	we := &Error{Err: err, wrapFlag: wrapFlag}
-	//caller function name
-	if fname, _, _ := caller(depth); len(fname) > 0 {
-		we = we.SetContext("function", fname)
-	}
+	cfg := getCallerConfig()
+	we := &Error{Err: err, wrapFlag: wrapFlag, capture: &captureOptions{disabled: cfg.Disabled}}
 	//other params
 	for _, opt := range opts {
 		opt(we)
 	}
+	capture := we.capture
+	we.capture = nil
+	if capture.disabled {
+		return we
+	}
+	//stack trace and caller information
+	if capture.deferred {
+		we.stack = deferredCallers(depth + capture.skip)
+	} else {
+		we.stack = callers(depth + capture.skip)
+	}
+	if f, ok := we.stack.caller(); ok {
+		we.setDefaultContext("function", cfg.functionName(f.Function))
+		if cfg.FileLine {
+			we.setDefaultContext("file", f.File)
+			we.setDefaultContext("line", f.Line)
+		}
+	}
 	return we
 }
 
@@ -79,11 +187,38 @@ func WithCause(err error) ErrorContextFunc {
 	}
 }
 
-//SetContext method sets context information
+//WithCauses function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that represents multiple cause errors.
+//Cause errors are joined by errors.Join function.
+func WithCauses(errs ...error) ErrorContextFunc {
+	return func(e *Error) {
+		_ = e.SetCause(joinErrors(errs...))
+	}
+}
+
+//joinErrors returns joined error instance. (internal)
+func joinErrors(errs ...error) error {
+	var last error
+	n := 0
+	for _, err := range errs {
+		if err != nil {
+			last = err
+			n++
+		}
+	}
+	if n <= 1 {
+		return last
+	}
+	return errors.Join(errs...)
+}
+
+//SetContext method sets context information.
+//SetContext method panics if the instance is frozen (use With method instead).
 func (e *Error) SetContext(name string, value interface{}) *Error {
 	if e == nil {
 		return e
 	}
+	e.mustNotFrozen("SetContext")
 	if e.Context == nil {
 		e.Context = map[string]interface{}{}
 	}
@@ -93,28 +228,126 @@ func (e *Error) SetContext(name string, value interface{}) *Error {
 	return e
 }
 
-//SetCause method sets cause error instance
+//SetCause method sets cause error instance.
+//If the tree of err has the instance itself, SetCause method sets ErrCyclicCause instead of err,
+//so errors.Is and errors.As functions of the standard library do not loop forever.
+//SetCause method panics if the instance is frozen.
 func (e *Error) SetCause(err error) *Error {
 	if e == nil {
 		return e
 	}
+	e.mustNotFrozen("SetCause")
+	if contains(err, e) {
+		err = ErrCyclicCause
+	}
 	e.Cause = err
 	return e
 }
 
-//Unwrap method returns cause error in Error instance.
-//This method is used in errors.Unwrap function.
-func (e *Error) Unwrap() error {
+//contains reports whether err's tree has the instance e. (internal)
+func contains(err error, e *Error) bool {
+	found := false
+	walk(err, func(n error) bool {
+		found = n == error(e)
+		return !found
+	})
+	return found
+}
+
+//With method returns a copy of the instance with context information.
+//The receiver is not modified, so With method is safe for concurrent use with other With calls and encoding of the receiver.
+func (e *Error) With(name string, value interface{}) *Error {
 	if e == nil {
-		return nil
+		return e
 	}
-	if e.Cause == nil {
-		if e.wrapFlag {
-			return e.Err
+	return e.Clone().SetContext(name, value)
+}
+
+//Freeze method makes the instance read-only, and returns it.
+//Setter methods (SetContext, SetCause, SetCode and so on) of frozen instance panic; use With method or Clone method instead.
+//Freeze method does not modify errors in the tree (Err and Cause), so freeze Error instances in the tree if they are modified elsewhere.
+//Freeze the instance before sharing it between goroutines.
+func (e *Error) Freeze() *Error {
+	if e == nil {
+		return e
+	}
+	e.frozen.Store(true)
+	return e
+}
+
+//Frozen method reports whether the instance is read-only.
+func (e *Error) Frozen() bool {
+	if e == nil {
+		return false
+	}
+	return e.frozen.Load()
+}
+
+//mustNotFrozen panics if the instance is frozen. (internal)
+func (e *Error) mustNotFrozen(method string) {
+	if e.frozen.Load() {
+		panic(fmt.Sprintf("errs: %s method of frozen %s instance", method, errorTypeName))
+	}
+}
+
+//Clone method returns a copy of the instance that is not frozen.
+//The copy is shallow: Context map and MessageArgs slice are copied,
+//but wrapped errors (Err and Cause) and context values are shared with the instance.
+func (e *Error) Clone() *Error {
+	if e == nil {
+		return e
+	}
+	c := &Error{
+		wrapFlag:      e.wrapFlag,
+		stack:         e.stack,
+		Err:           e.Err,
+		Cause:         e.Cause,
+		Code:          e.Code,
+		Kind:          e.Kind,
+		MessageID:     e.MessageID,
+		PublicMessage: e.PublicMessage,
+	}
+	if e.MessageArgs != nil {
+		c.MessageArgs = append(make([]interface{}, 0, len(e.MessageArgs)), e.MessageArgs...)
+	}
+	if e.Context != nil {
+		c.Context = make(map[string]interface{}, len(e.Context))
+		for k, v := range e.Context {
+			c.Context[k] = v
 		}
+	}
+	return c
+}
+
+//StackTrace method returns stack trace at creating Error instance by New and Wrap functions.
+func (e *Error) StackTrace() StackTrace {
+	if e == nil {
+		return nil
+	}
+	return e.stack.StackTrace()
+}
+
+//Unwrap method returns wrapped errors in Error instance.
+//This method is used in errors.Is and errors.As functions.
+//
+//Unwrap method returns the Err and Cause branches in this order.
+//The Err branch is included only if the instance is created by Wrap function (or decoded from JSON data),
+//because Err of instance created by New function is just a message.
+func (e *Error) Unwrap() []error {
+	if e == nil {
 		return nil
 	}
-	return e.Cause
+	errs := make([]error, 0, 2)
+	if e.wrapFlag && e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
 }
 
 //Is method reports whether any error in error's chain matches cause of target error.
@@ -123,19 +356,10 @@ func (e *Error) Is(target error) bool {
 	if e == target {
 		return true
 	}
-	if e != nil {
-		if errors.Is(e.Err, target) {
-			return true
-		}
-		if errors.Is(e.Cause, target) {
-			return true
-		}
-		cause := Cause(target)
-		if cause != nil && cause != target && errors.Is(e, cause) {
-			return true
-		}
+	if e == nil || target == nil {
+		return false
 	}
-	return false
+	return is(e, target)
 }
 
 //Error method returns error message.
@@ -144,13 +368,7 @@ func (e *Error) Error() string {
 	if e == nil {
 		return nilAngleString
 	}
-	if e.Cause == nil {
-		return e.Err.Error()
-	}
-	if len(e.Err.Error()) == 0 {
-		return e.Cause.Error()
-	}
-	return fmt.Sprintf("%v: %v", e.Err, e.Cause)
+	return redactString(errorMessage(e))
 }
 
 //String method returns error message.
@@ -165,7 +383,27 @@ func (e *Error) GoString() string {
 	if e == nil {
 		return nilAngleString
 	}
-	return fmt.Sprintf("%T{Err:%#v, Cause:%#v, Context:%#v}", e, e.Err, e.Cause, e.Context)
+	return redactString(newGuard().goString(e))
+}
+
+//goString method returns serialize string of Error with guard. (internal)
+func (g *guard) goString(e *Error) string {
+	switch g.enter(e) {
+	case guardCycle:
+		return cycleString
+	case guardTruncated:
+		return truncatedString
+	}
+	defer g.leave()
+	return fmt.Sprintf("%T{Err:%s, Cause:%s, Context:%#v}", e, g.goStringOf(e.Err), g.goStringOf(e.Cause), e.SafeContext())
+}
+
+//goStringOf method returns Go syntax representation of err. (internal)
+func (g *guard) goStringOf(err error) string {
+	if e, ok := err.(*Error); ok && e != nil {
+		return g.goString(e)
+	}
+	return fmt.Sprintf("%#v", err)
 }
 
 //MarshalJSON method returns serialize string of Error with JSON format.
@@ -175,24 +413,11 @@ func (e *Error) MarshalJSON() ([]byte, error) {
 }
 
 //EncodeJSON method returns serialize string of Error with JSON format.
-func (e *Error) EncodeJSON() string {
+func (e *Error) EncodeJSON(opts ...EncodeOption) string {
 	if e == nil {
 		return "null"
 	}
-	elms := []string{}
-	elms = append(elms, fmt.Sprintf(`"Type":%q`, fmt.Sprintf("%T", e)))
-	msgBuf := &bytes.Buffer{}
-	json.HTMLEscape(msgBuf, []byte(fmt.Sprintf(`"Err":%s`, EncodeJSON(e.Err))))
-	elms = append(elms, msgBuf.String())
-	if len(e.Context) > 0 {
-		if b, err := json.Marshal(e.Context); err == nil {
-			elms = append(elms, fmt.Sprintf(`"Context":%s`, string(b)))
-		}
-	}
-	if e.Cause != nil && !reflect.ValueOf(e.Cause).IsZero() {
-		elms = append(elms, fmt.Sprintf(`"Cause":%s`, EncodeJSON(e.Cause)))
-	}
-	return "{" + strings.Join(elms, ",") + "}"
+	return encodeToString(e, opts...)
 }
 
 //Format method returns formatted string of Error instance.
@@ -204,7 +429,10 @@ func (e *Error) Format(s fmt.State, verb rune) {
 		case s.Flag('#'):
 			_, _ = strings.NewReader(e.GoString()).WriteTo(s)
 		case s.Flag('+'):
-			_, _ = strings.NewReader(e.EncodeJSON()).WriteTo(s)
+			es := newEncodeState()
+			es.encodeTop(e, encodeOptions{})
+			_, _ = es.buf.WriteTo(s)
+			es.free()
 		default:
 			_, _ = strings.NewReader(e.Error()).WriteTo(s)
 		}
@@ -216,65 +444,100 @@ func (e *Error) Format(s fmt.State, verb rune) {
 }
 
 //Cause function finds cause error in target error instance.
+//If Error instance has both Err and Cause, Cause function follows the Cause branch.
+//If error instance has multiple cause errors, Cause function follows the first one.
+//If the chain is cyclic or deeper than MaxDepth, Cause function returns the last error before the cycle or the limit.
 func Cause(err error) error {
-	for err != nil {
-		unwraped := errors.Unwrap(err)
-		if unwraped == nil {
+	g := newGuard()
+	for err != nil && g.enter(err) == guardOK {
+		unwraped := causeBranches(err)
+		if len(unwraped) == 0 {
 			return err
 		}
-		err = unwraped
+		err = unwraped[0]
+	}
+	if len(g.path) == 0 {
+		return err
 	}
-	return err
+	return g.path[len(g.path)-1]
 }
 
-//caller returns caller info.
-func caller(depth int) (string, string, int) {
-	pc, src, line, ok := runtime.Caller(depth + 1)
-	if !ok {
-		return "", "", 0
+//Causes function finds cause errors in every branch of target error instance.
+//If Error instance has both Err and Cause, Causes function follows the Cause branch.
+//Cyclic branches are skipped, and branches deeper than MaxDepth are cut off.
+func Causes(err error) []error {
+	if err == nil {
+		return nil
 	}
-	return runtime.FuncForPC(pc).Name(), src, line
+	g := newGuard()
+	_ = g.enter(err)
+	return g.causes(err)
 }
 
-//EncodeJSON function dumps out error instance with JSON format.
-func EncodeJSON(err error) string {
-	if e, ok := err.(*Error); ok {
-		return e.EncodeJSON()
-	}
-	if e, ok := err.(json.Marshaler); ok {
-		b, ee := json.Marshal(e)
-		if ee != nil {
-			return encodeJSON(err)
+//causes method finds cause errors in every branch of err. (internal)
+func (g *guard) causes(err error) []error {
+	causes := []error{}
+	for _, e := range causeBranches(err) {
+		switch g.enter(e) {
+		case guardOK:
+			causes = append(causes, g.causes(e)...)
+			g.leave()
+		case guardTruncated:
+			causes = append(causes, e)
 		}
-		return strings.TrimSpace(string(b))
 	}
-	return encodeJSON(err)
+	if len(causes) == 0 {
+		return []error{err}
+	}
+	return causes
 }
 
-func encodeJSON(err error) string {
-	if err == nil {
-		return "null"
+//causeBranches returns branches of cause errors. (internal)
+func causeBranches(err error) []error {
+	if e, ok := err.(*Error); ok && e != nil && e.Cause != nil {
+		return []error{e.Cause}
+	}
+	return unwrapAll(err)
+}
+
+//unwrapAll returns all non-nil errors unwrapped from err. (internal)
+func unwrapAll(err error) []error {
+	bs := branchesOf(err)
+	if len(bs) == 0 {
+		return nil
 	}
-	elms := []string{}
-	elms = append(elms, fmt.Sprintf(`"Type":%q`, fmt.Sprintf("%T", err)))
-	msgBuf := &bytes.Buffer{}
-	json.HTMLEscape(msgBuf, []byte(fmt.Sprintf(`"Msg":%q`, err.Error())))
-	elms = append(elms, msgBuf.String())
-	unwraped := errors.Unwrap(err)
-	if unwraped != nil {
-		elms = append(elms, fmt.Sprintf(`"Cause":%s`, EncodeJSON(unwraped)))
+	errs := make([]error, 0, len(bs))
+	for _, b := range bs {
+		errs = append(errs, b.err)
 	}
-	return "{" + strings.Join(elms, ",") + "}"
+	return errs
 }
 
-// Is is conpatible with errors.Is.
-func Is(err, target error) bool { return errors.Is(err, target) }
+//EncodeJSON function dumps out error instance with JSON format.
+func EncodeJSON(err error, opts ...EncodeOption) string {
+	return encodeToString(err, opts...)
+}
 
-// As is conpatible with errors.As.
-func As(err error, target interface{}) bool { return errors.As(err, target) }
+// Is is compatible with errors.Is.
+// Is function detects cycles and respects MaxDepth in traversal of err's tree.
+func Is(err, target error) bool { return is(err, target) }
 
-// Unwrap is conpatible with errors.Unwrap.
-func Unwrap(err error) error { return errors.Unwrap(err) }
+// As is compatible with errors.As.
+// As function detects cycles and respects MaxDepth in traversal of err's tree.
+func As(err error, target interface{}) bool { return as(err, target) }
+
+// Unwrap returns the first wrapped error of err.
+// Unlike errors.Unwrap function, Unwrap function also follows Unwrap() []error method and returns the first one,
+// so Unwrap(Wrap(x, WithCause(y))) returns x (Err branch), not y. Use Cause function to find the cause.
+//
+// Note: Error type has Unwrap() []error method (not Unwrap() error method) since multi-error support,
+// so errors.Unwrap function always returns nil for Error instances. Use this function instead.
+func Unwrap(err error) error {
+	if errs := unwrapAll(err); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
 
 /* Copyright 2019-2021 Spiegel
  *
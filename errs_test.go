@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"syscall"
 	"testing"
 )
@@ -39,6 +41,24 @@ func (t *testError) MarshalJSON() ([]byte, error) {
 	return []byte("{" + strings.Join(elms, ",") + "}"), nil
 }
 
+//trimStackTrace removes environment-dependent "StackTrace" elements from JSON string.
+//The value of each element is parsed as JSON, so function names with brackets (generics) are also removed.
+func trimStackTrace(s string) string {
+	const key = `,"StackTrace":`
+	for {
+		i := strings.Index(s, key)
+		if i < 0 {
+			return s
+		}
+		dec := json.NewDecoder(strings.NewReader(s[i+len(key):]))
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return s
+		}
+		s = s[:i] + s[i+len(key)+int(dec.InputOffset()):]
+	}
+}
+
 var (
 	nilErr         = New("") // nil object
 	nilValueErr    = (*Error)(nil)
@@ -47,6 +67,23 @@ var (
 	wrapedErrTest2 = &testError{Msg: "test for testError", Err: wrapedErrTest}
 )
 
+func TestTrimStackTrace(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want string
+	}{
+		{s: `{"Type":"*errs.Error"}`, want: `{"Type":"*errs.Error"}`},
+		{s: `{"Type":"*errs.Error","StackTrace":[{"Function":"main.F","File":"main.go","Line":1}]}`, want: `{"Type":"*errs.Error"}`},
+		{s: `{"Type":"*errs.Error","StackTrace":[{"Function":"main.F[...]","File":"main.go","Line":1},{"Function":"main.G[go.shape.int]","File":"main.go","Line":2}],"Fields":{}}`, want: `{"Type":"*errs.Error","Fields":{}}`},
+		{s: `{"Type":"*errs.Error","Cause":{"Type":"*errs.Error","StackTrace":[{"Function":"main.F[...]"}]},"StackTrace":[{"Function":"main.G[...]"}]}`, want: `{"Type":"*errs.Error","Cause":{"Type":"*errs.Error"}}`},
+	}
+	for _, tc := range testCases {
+		if str := trimStackTrace(tc.s); str != tc.want {
+			t.Errorf("trimStackTrace(%q) is %q, want %q", tc.s, str, tc.want)
+		}
+	}
+}
+
 func TestNil(t *testing.T) {
 	testCases := []struct {
 		err     error
@@ -195,7 +232,7 @@ func TestNewWithCause(t *testing.T) {
 		if str != tc.detail {
 			t.Errorf("Wrap(\"%v\") is %v, want %v", tc.err, str, tc.detail)
 		}
-		str = fmt.Sprintf("%+v", err)
+		str = trimStackTrace(fmt.Sprintf("%+v", err))
 		if str != tc.json {
 			t.Errorf("Wrap(\"%v\") is %v, want %v", tc.err, str, tc.json)
 		}
@@ -207,10 +244,10 @@ func TestNewWithCause(t *testing.T) {
 			b, e := json.Marshal(err)
 			if e != nil {
 				t.Errorf("json.Marshal(\"%v\") is %v, want <nil>", tc.err, e)
-			} else if string(b) != tc.json {
+			} else if trimStackTrace(string(b)) != tc.json {
 				t.Errorf("Wrap(\"%v\") is %v, want %v", tc.err, string(b), tc.json)
 			}
-			str = EncodeJSON(err)
+			str = trimStackTrace(EncodeJSON(err))
 			if str != tc.json {
 				t.Errorf("Wrap(\"%v\") is %v, want %v", tc.err, str, tc.json)
 			}
@@ -304,7 +341,7 @@ func TestWrapWithCause(t *testing.T) {
 		if str != tc.detail {
 			t.Errorf("Wrap(\"%v\") is %v, want %v", tc.err, str, tc.detail)
 		}
-		str = fmt.Sprintf("%+v", err)
+		str = trimStackTrace(fmt.Sprintf("%+v", err))
 		if str != tc.json {
 			t.Errorf("Wrap(\"%v\") is %v, want %v", tc.err, str, tc.json)
 		}
@@ -316,10 +353,10 @@ func TestWrapWithCause(t *testing.T) {
 			b, e := json.Marshal(err)
 			if e != nil {
 				t.Errorf("json.Marshal(\"%v\") is %v, want <nil>", tc.err, e)
-			} else if string(b) != tc.json {
+			} else if trimStackTrace(string(b)) != tc.json {
 				t.Errorf("Wrap(\"%v\") is %v, want %v", tc.err, string(b), tc.json)
 			}
-			str = EncodeJSON(err)
+			str = trimStackTrace(EncodeJSON(err))
 			if str != tc.json {
 				t.Errorf("Wrap(\"%v\") is %v, want %v", tc.err, str, tc.json)
 			}
@@ -405,6 +442,8 @@ func TestUnwrap(t *testing.T) {
 		{err: New("wrapped error"), unwrap: "", cause: nil},
 		{err: New("wrapped error", WithCause(syscall.ENOENT)), unwrap: "no such file or directory", cause: syscall.ENOENT},
 		{err: Wrap(syscall.ENOENT), unwrap: "no such file or directory", cause: syscall.ENOENT},
+		{err: errors.Join(syscall.ENOENT, os.ErrInvalid), unwrap: "no such file or directory", cause: syscall.ENOENT},
+		{err: Wrap(os.ErrInvalid, WithCause(syscall.ENOENT)), unwrap: "invalid argument", cause: os.ErrInvalid},
 	}
 
 	for _, tc := range testCases {
@@ -419,6 +458,350 @@ func TestUnwrap(t *testing.T) {
 			t.Errorf("As(\"%v\") = \"%v\", want \"%v\"", tc.err, cs, tc.cause)
 		}
 	}
+	//Error type has Unwrap() []error method only (breaking change)
+	if cs := errors.Unwrap(Wrap(syscall.ENOENT)); cs != nil {
+		t.Errorf("errors.Unwrap() = \"%v\", want <nil>", cs)
+	}
+}
+
+type openError struct {
+	path string
+}
+
+func (e *openError) Error() string {
+	return "file open error: " + e.path
+}
+
+func TestIsAsAgreement(t *testing.T) {
+	pathErr := &os.PathError{Op: "open", Path: "not-exist.txt", Err: syscall.ENOENT}
+	openErr := &openError{path: "not-exist.txt"}
+	testCases := []struct {
+		name  string
+		err   error
+		cause error
+	}{
+		{name: "New with cause", err: New("file open error", WithCause(pathErr), WithContext("path", "not-exist.txt")), cause: syscall.ENOENT},
+		{name: "Wrap", err: Wrap(pathErr, WithContext("path", "not-exist.txt")), cause: syscall.ENOENT},
+		{name: "Wrap with cause", err: Wrap(openErr, WithCause(pathErr), WithContext("path", "not-exist.txt")), cause: syscall.ENOENT},
+		{name: "Wrap with cause (nested)", err: Wrap(Wrap(openErr), WithCause(New("wrapped error", WithCause(pathErr)))), cause: syscall.ENOENT},
+	}
+
+	for _, tc := range testCases {
+		var pe *fs.PathError
+		if isOK, asOK := errors.Is(tc.err, pathErr), errors.As(tc.err, &pe); isOK != asOK || (asOK && pe != pathErr) {
+			t.Errorf("%s: errors.Is() is %v and errors.As() is %v (%v), want same result", tc.name, isOK, asOK, pe)
+		}
+		var oe *openError
+		if isOK, asOK := errors.Is(tc.err, openErr), errors.As(tc.err, &oe); isOK != asOK || (asOK && oe != openErr) {
+			t.Errorf("%s: errors.Is() is %v and errors.As() is %v (%v), want same result", tc.name, isOK, asOK, oe)
+		}
+		var errno syscall.Errno
+		if isOK, asOK := errors.Is(tc.err, syscall.ENOENT), errors.As(tc.err, &errno); isOK != asOK || (asOK && errno != syscall.ENOENT) {
+			t.Errorf("%s: errors.Is() is %v and errors.As() is %v (%v), want same result", tc.name, isOK, asOK, errno)
+		}
+		if c := Cause(tc.err); c != tc.cause {
+			t.Errorf("%s: Cause() is \"%v\", want \"%v\"", tc.name, c, tc.cause)
+		}
+	}
+}
+
+func TestJoin(t *testing.T) {
+	testCases := []struct {
+		errs   []error
+		isNil  bool
+		msg    string
+		json   string
+		causes []error
+	}{
+		{errs: nil, isNil: true},
+		{errs: []error{nil, nil}, isNil: true},
+		{errs: []error{os.ErrInvalid}, msg: "invalid argument", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.joinError","Msg":"invalid argument","Causes":[{"Type":"*errors.errorString","Msg":"invalid argument"}]},"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestJoin"}}`, causes: []error{os.ErrInvalid}},
+		{errs: []error{os.ErrInvalid, nil, syscall.ENOENT}, msg: "invalid argument\nno such file or directory", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.joinError","Msg":"invalid argument\nno such file or directory","Causes":[{"Type":"*errors.errorString","Msg":"invalid argument"},{"Type":"syscall.Errno","Msg":"no such file or directory"}]},"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestJoin"}}`, causes: []error{os.ErrInvalid, syscall.ENOENT}},
+	}
+
+	for _, tc := range testCases {
+		err := Join(tc.errs...)
+		if tc.isNil {
+			if err != nil {
+				t.Errorf("Join(%v) is \"%v\", want <nil>", tc.errs, err)
+			}
+			continue
+		}
+		if str := err.Error(); str != tc.msg {
+			t.Errorf("Join(%v) is %q, want %q", tc.errs, str, tc.msg)
+		}
+		if str := trimStackTrace(EncodeJSON(err)); str != tc.json {
+			t.Errorf("Join(%v) is %v, want %v", tc.errs, str, tc.json)
+		}
+		for _, target := range tc.errs {
+			if target != nil && !Is(err, target) {
+				t.Errorf("Is(Join(%v), \"%v\") is false, want true", tc.errs, target)
+			}
+		}
+		causes := Causes(err)
+		if len(causes) != len(tc.causes) {
+			t.Errorf("Causes(Join(%v)) is %v, want %v", tc.errs, causes, tc.causes)
+			continue
+		}
+		for i := range causes {
+			if causes[i] != tc.causes[i] {
+				t.Errorf("Causes(Join(%v))[%d] is \"%v\", want \"%v\"", tc.errs, i, causes[i], tc.causes[i])
+			}
+		}
+	}
+}
+
+func TestWithCauses(t *testing.T) {
+	testCases := []struct {
+		causes []error
+		msg    string
+		json   string
+		cause  error
+	}{
+		{causes: nil, msg: "wrapped error", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"wrapped error"},"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestWithCauses"}}`, cause: nil},
+		{causes: []error{nil, os.ErrInvalid}, msg: "wrapped error: invalid argument", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"wrapped error"},"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestWithCauses"},"Cause":{"Type":"*errors.errorString","Msg":"invalid argument"}}`, cause: os.ErrInvalid},
+		{causes: []error{os.ErrInvalid, syscall.ENOENT}, msg: "wrapped error: invalid argument\nno such file or directory", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"wrapped error"},"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestWithCauses"},"Cause":{"Type":"*errors.joinError","Msg":"invalid argument\nno such file or directory","Causes":[{"Type":"*errors.errorString","Msg":"invalid argument"},{"Type":"syscall.Errno","Msg":"no such file or directory"}]}}`, cause: os.ErrInvalid},
+	}
+
+	for _, tc := range testCases {
+		err := New("wrapped error", WithCauses(tc.causes...))
+		if str := err.Error(); str != tc.msg {
+			t.Errorf("New(WithCauses(%v)) is %q, want %q", tc.causes, str, tc.msg)
+		}
+		if str := trimStackTrace(EncodeJSON(err)); str != tc.json {
+			t.Errorf("New(WithCauses(%v)) is %v, want %v", tc.causes, str, tc.json)
+		}
+		for _, target := range tc.causes {
+			if target != nil && !Is(err, target) {
+				t.Errorf("Is(New(WithCauses(%v)), \"%v\") is false, want true", tc.causes, target)
+			}
+		}
+		if tc.cause != nil {
+			if c := Cause(err); c != tc.cause {
+				t.Errorf("Cause(New(WithCauses(%v))) is \"%v\", want \"%v\"", tc.causes, c, tc.cause)
+			}
+		}
+	}
+}
+
+func TestNewf(t *testing.T) {
+	testCases := []struct {
+		err     error
+		isNil   bool
+		msg     string
+		json    string
+		targets []error
+	}{
+		{err: Newf(""), isNil: true},
+		{err: Newf("error %d", 1, WithContext("foo", "bar")), msg: "error 1", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"error 1"},"Context":{"foo":"bar","function":"github.com/spiegel-im-spiegel/errs.TestNewf"}}`},
+		{err: Newf("open %s: %w", "file", os.ErrNotExist), msg: "open file: file does not exist", json: `{"Type":"*errs.Error","Err":{"Type":"*fmt.wrapError","Msg":"open file: file does not exist","Cause":{"Type":"*errors.errorString","Msg":"file does not exist"}},"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestNewf"}}`, targets: []error{os.ErrNotExist}},
+		{err: Newf("%w and %w", os.ErrInvalid, syscall.ENOENT, WithCode(codeTest)), msg: "invalid argument and no such file or directory", targets: []error{os.ErrInvalid, syscall.ENOENT}},
+	}
+
+	for _, tc := range testCases {
+		if tc.isNil {
+			if tc.err != nil {
+				t.Errorf("Newf() is \"%v\", want <nil>", tc.err)
+			}
+			continue
+		}
+		if str := tc.err.Error(); str != tc.msg {
+			t.Errorf("Newf() is %q, want %q", str, tc.msg)
+		}
+		if str := trimStackTrace(EncodeJSON(tc.err)); len(tc.json) > 0 && str != tc.json {
+			t.Errorf("Newf() is %v, want %v", str, tc.json)
+		}
+		for _, target := range tc.targets {
+			if !errors.Is(tc.err, target) || !Is(tc.err, target) {
+				t.Errorf("Is(Newf(), \"%v\") is false, want true", target)
+			}
+		}
+		if errors.Is(tc.err, os.ErrPermission) {
+			t.Errorf("Is(Newf(), \"%v\") is true, want false", os.ErrPermission)
+		}
+	}
+}
+
+func TestWrapf(t *testing.T) {
+	testCases := []struct {
+		err     error
+		isNil   bool
+		msg     string
+		json    string
+		targets []error
+	}{
+		{err: Wrapf(nil, "error"), isNil: true},
+		{err: Wrapf(os.ErrNotExist, "open %s", "file", WithContext("foo", "bar")), msg: "open file: file does not exist", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"open file"},"Context":{"foo":"bar","function":"github.com/spiegel-im-spiegel/errs.TestWrapf"},"Cause":{"Type":"*errors.errorString","Msg":"file does not exist"}}`, targets: []error{os.ErrNotExist}},
+		{err: Wrapf(os.ErrNotExist, "", WithContext("foo", "bar")), msg: "file does not exist", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"file does not exist"},"Context":{"foo":"bar","function":"github.com/spiegel-im-spiegel/errs.TestWrapf"}}`, targets: []error{os.ErrNotExist}},
+		{err: Wrapf(os.ErrNotExist, "open (%w)", os.ErrInvalid), msg: "open (invalid argument): file does not exist", targets: []error{os.ErrNotExist, os.ErrInvalid}},
+	}
+
+	for _, tc := range testCases {
+		if tc.isNil {
+			if tc.err != nil {
+				t.Errorf("Wrapf() is \"%v\", want <nil>", tc.err)
+			}
+			continue
+		}
+		if str := tc.err.Error(); str != tc.msg {
+			t.Errorf("Wrapf() is %q, want %q", str, tc.msg)
+		}
+		if str := trimStackTrace(EncodeJSON(tc.err)); len(tc.json) > 0 && str != tc.json {
+			t.Errorf("Wrapf() is %v, want %v", str, tc.json)
+		}
+		for _, target := range tc.targets {
+			if !errors.Is(tc.err, target) || !Is(tc.err, target) {
+				t.Errorf("Is(Wrapf(), \"%v\") is false, want true", target)
+			}
+		}
+	}
+}
+
+func TestWith(t *testing.T) {
+	e := New("error", WithContext("foo", 1)).(*Error)
+	w := e.With("bar", 2)
+	if w == e {
+		t.Error("With() returns the receiver, want a copy")
+	}
+	if len(e.Context) != 2 {
+		t.Errorf("Context of receiver is %v, want unchanged", e.Context)
+	}
+	if w.Context["foo"] != 1 || w.Context["bar"] != 2 {
+		t.Errorf("Context of With() is %v, want foo and bar", w.Context)
+	}
+	if w.Err != e.Err {
+		t.Errorf("Err of With() is %v, want %v", w.Err, e.Err)
+	}
+	if w.StackTrace() == nil {
+		t.Error("StackTrace() of With() is nil, want shared stack trace")
+	}
+	if n := (*Error)(nil).With("foo", 1); n != nil {
+		t.Errorf("nil.With() is %v, want <nil>", n)
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	e := Wrap(os.ErrInvalid, WithContext("foo", 1)).(*Error).Freeze()
+	if !e.Frozen() {
+		t.Error("Frozen() is false, want true")
+	}
+	want := EncodeJSON(e)
+	testCases := []struct {
+		name string
+		f    func()
+	}{
+		{name: "SetContext", f: func() { e.SetContext("bar", 2) }},
+		{name: "SetCause", f: func() { e.SetCause(os.ErrNotExist) }},
+		{name: "SetCode", f: func() { e.SetCode("TEST-001") }},
+		{name: "SetKind", f: func() { e.SetKind(NotFound) }},
+		{name: "SetMessageID", f: func() { e.SetMessageID("test.message") }},
+		{name: "SetPublicMessage", f: func() { e.SetPublicMessage("try again later") }},
+	}
+	for _, tc := range testCases {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s() of frozen instance does not panic", tc.name)
+				}
+			}()
+			tc.f()
+		}()
+		if str := EncodeJSON(e); str != want {
+			t.Errorf("%s() modifies frozen instance: %v, want %v", tc.name, str, want)
+		}
+	}
+	if err := e.UnmarshalJSON([]byte(want)); err == nil {
+		t.Error("UnmarshalJSON() of frozen instance is nil, want error")
+	}
+	if c := e.With("bar", 2); c == e || c.Frozen() || c.Context["bar"] != 2 {
+		t.Errorf("With() of frozen instance is %v (frozen: %v), want modified copy", c, c.Frozen())
+	}
+
+	inner := New("inner", WithContext("foo", 1)).(*Error)
+	outer := Wrap(inner).(*Error).Freeze()
+	if inner.Frozen() {
+		t.Error("Frozen() of nested instance is true, want false")
+	}
+	if c := inner.SetContext("foo", 2); c != inner || c.Context["foo"] != 2 {
+		t.Errorf("SetContext() of nested instance is %v, want the receiver", c)
+	}
+	if !outer.Frozen() {
+		t.Error("Frozen() is false, want true")
+	}
+}
+
+func TestClone(t *testing.T) {
+	e := New("error", WithCause(os.ErrInvalid), WithCode("TEST-001"), WithContext("foo", 1)).(*Error).Freeze()
+	c := e.Clone()
+	if c == e || c.Frozen() {
+		t.Errorf("Clone() is %p (frozen: %v), want new instance that is not frozen", c, c.Frozen())
+	}
+	if c.Err != e.Err || c.Cause != e.Cause || c.Code != e.Code || c.wrapFlag != e.wrapFlag || c.stack != e.stack {
+		t.Errorf("Clone() is %#v, want %#v", c, e)
+	}
+	_ = c.SetContext("foo", 2)
+	if e.Context["foo"] != 1 {
+		t.Errorf("Context of original is %v, want unchanged", e.Context)
+	}
+	m := New("message", WithMessageID("test.message", 1)).(*Error)
+	mc := m.Clone()
+	mc.MessageArgs[0] = 2
+	if m.MessageArgs[0] != 1 {
+		t.Errorf("MessageArgs of original is %v, want unchanged", m.MessageArgs)
+	}
+	if n := (*Error)(nil).Clone(); n != nil {
+		t.Errorf("nil.Clone() is %v, want <nil>", n)
+	}
+}
+
+func TestConcurrentAnnotation(t *testing.T) {
+	base := Wrap(os.ErrInvalid, WithContext("request", "abc")).(*Error).Freeze()
+	var wg sync.WaitGroup
+	results := make([]*Error, 16)
+	for i := 0; i < len(results); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			e := base.With("worker", i).SetContext("done", true)
+			_ = base.Freeze().Frozen()
+			_ = EncodeJSON(base)
+			_ = fmt.Sprintf("%v %#v %+v", base, base, e)
+			_ = base.StackTrace()
+			_ = LogValue(base)
+			results[i] = e
+		}(i)
+	}
+	wg.Wait()
+	if _, ok := base.Context["worker"]; ok || len(base.Context) != 2 {
+		t.Errorf("Context of frozen instance is %v, want unchanged", base.Context)
+	}
+	for i, e := range results {
+		if e.Context["worker"] != i || e.Context["done"] != true || e.Context["request"] != "abc" {
+			t.Errorf("Context of worker %d is %v", i, e.Context)
+		}
+	}
+}
+
+func TestConcurrentFreeze(t *testing.T) {
+	shared := New("shared", WithCause(os.ErrInvalid), WithContext("foo", 1)).(*Error)
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			e := Wrap(shared, WithCause(os.ErrNotExist), WithContext("worker", i)).(*Error).Freeze()
+			_ = shared.Freeze()
+			_ = Wrap(shared).(*Error).Freeze()
+			_ = shared.Frozen()
+			_ = EncodeJSON(e)
+			_ = fmt.Sprintf("%v %#v %+v", e, e, e)
+			_ = Is(e, os.ErrInvalid)
+		}(i)
+	}
+	wg.Wait()
+	if !shared.Frozen() {
+		t.Error("Frozen() is false, want true")
+	}
 }
 
 /* Copyright 2019-2021 Spiegel
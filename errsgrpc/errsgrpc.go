@@ -0,0 +1,381 @@
+// Package errsgrpc converts error instances to gRPC status with error details, and vice versa.
+package errsgrpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+const (
+	//DefaultDomain is default value of ErrorInfo.Domain.
+	DefaultDomain = "github.com/spiegel-im-spiegel/errs"
+)
+
+//Converter type converts error instances to gRPC status, and vice versa.
+type Converter struct {
+	codes     map[errs.Code]codes.Code
+	kinds     map[*errs.Kind]codes.Code
+	reverse   map[codes.Code]*errs.Kind
+	fallback  codes.Code
+	domain    string
+	metadata  []string
+	debugInfo bool
+}
+
+//Option type is self-referential function type for NewConverter function. (functional options pattern)
+type Option func(*Converter)
+
+//WithCode function returns Option function value.
+//This function is used in NewConverter function that represents mapping of error code to gRPC status code.
+func WithCode(code errs.Code, c codes.Code) Option {
+	return func(cv *Converter) {
+		cv.codes[code] = c
+	}
+}
+
+//WithKind function returns Option function value.
+//This function is used in NewConverter function that represents mapping of error kind (and its descendant kinds) to gRPC status code.
+//Mapping of error code takes precedence over mapping of error kind.
+//Error method attaches the first kind mapped to the status code to error instance.
+func WithKind(kind *errs.Kind, c codes.Code) Option {
+	return func(cv *Converter) {
+		cv.kinds[kind] = c
+		if _, ok := cv.reverse[c]; !ok {
+			cv.reverse[c] = kind
+		}
+	}
+}
+
+//StandardKinds is mapping of predefined error kinds to gRPC status codes.
+var StandardKinds = map[*errs.Kind]codes.Code{
+	errs.NotFound:    codes.NotFound,
+	errs.Permission:  codes.PermissionDenied,
+	errs.Invalid:     codes.InvalidArgument,
+	errs.Conflict:    codes.AlreadyExists,
+	errs.Unavailable: codes.Unavailable,
+	errs.Timeout:     codes.DeadlineExceeded,
+	errs.Canceled:    codes.Canceled,
+	errs.Internal:    codes.Internal,
+}
+
+//WithStandardKinds function returns Option function value.
+//This function is used in NewConverter function that represents mapping of predefined error kinds by StandardKinds.
+//Note that standard-library errors are classified automatically (e.g. context.DeadlineExceeded is Timeout kind).
+func WithStandardKinds() Option {
+	return func(cv *Converter) {
+		for kind, c := range StandardKinds {
+			WithKind(kind, c)(cv)
+		}
+	}
+}
+
+//WithFallback function returns Option function value.
+//This function is used in NewConverter function that represents gRPC status code for errors without mapped error code and kind.
+//(Default is codes.Unknown.)
+func WithFallback(c codes.Code) Option {
+	return func(cv *Converter) {
+		cv.fallback = c
+	}
+}
+
+//WithDomain function returns Option function value.
+//This function is used in NewConverter function that represents domain of ErrorInfo.
+func WithDomain(domain string) Option {
+	return func(cv *Converter) {
+		cv.domain = domain
+	}
+}
+
+//WithMetadata function returns Option function value.
+//This function is used in NewConverter function that represents whitelist of context keys output as ErrorInfo.Metadata.
+//(Default is no keys.)
+func WithMetadata(keys ...string) Option {
+	return func(cv *Converter) {
+		cv.metadata = append(cv.metadata, keys...)
+	}
+}
+
+//WithDebugInfo function returns Option function value.
+//This function is used in NewConverter function that represents whether DebugInfo (stack trace and cause chain) is attached to gRPC status.
+//(Default is false.)
+//Do not attach DebugInfo to status for untrusted clients, because internal cause messages, stack trace and context values leak.
+func WithDebugInfo(flag bool) Option {
+	return func(cv *Converter) {
+		cv.debugInfo = flag
+	}
+}
+
+//NewConverter function returns Converter instance.
+func NewConverter(opts ...Option) *Converter {
+	cv := &Converter{
+		codes:     map[errs.Code]codes.Code{},
+		kinds:     map[*errs.Kind]codes.Code{},
+		reverse:   map[codes.Code]*errs.Kind{},
+		fallback:  codes.Unknown,
+		domain:    DefaultDomain,
+		debugInfo: false,
+	}
+	for _, opt := range opts {
+		opt(cv)
+	}
+	return cv
+}
+
+var defaultConverter = NewConverter()
+
+//Status method converts error instance to gRPC status.
+//Error code is mapped to gRPC status code and ErrorInfo.Reason, context of whitelisted keys (see WithMetadata function) to ErrorInfo.Metadata,
+//and stack trace and cause chain to DebugInfo (see WithDebugInfo function).
+//Message of the status is user-facing message (see errs.PublicMessage function),
+//so internal cause messages are not contained in the status unless DebugInfo is attached by WithDebugInfo option.
+//If err is a gRPC status error, Status method returns the status as it is.
+func (cv *Converter) Status(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	if se, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return se.GRPCStatus()
+	}
+	code := errs.CodeOf(err)
+	c, ok := cv.codes[code]
+	if !ok {
+		c, ok = cv.kindCode(errs.KindOf(err))
+	}
+	if !ok {
+		c = cv.fallback
+		//errs.As function instead of status.FromError function (errors.As function) for possibly cyclic tree
+		var se interface{ GRPCStatus() *status.Status }
+		if errs.As(err, &se) {
+			c = se.GRPCStatus().Code()
+		}
+	}
+	st := status.New(c, errs.PublicMessage(err))
+	reason := string(code)
+	if len(reason) == 0 {
+		reason = c.String()
+	}
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: cv.domain, Metadata: cv.metadataOf(err)}}
+	if cv.debugInfo {
+		details = append(details, &errdetails.DebugInfo{StackEntries: stackEntries(err), Detail: errs.EncodeJSON(err)})
+	}
+	if ds, e := st.WithDetails(details...); e == nil {
+		return ds
+	}
+	return st
+}
+
+//kindCode returns gRPC status code of kind or its nearest ancestor kind.
+func (cv *Converter) kindCode(kind *errs.Kind) (codes.Code, bool) {
+	for k := kind; k != nil; k = k.Parent() {
+		if c, ok := cv.kinds[k]; ok {
+			return c, true
+		}
+	}
+	return codes.Unknown, false
+}
+
+//Status function converts error instance to gRPC status by default Converter.
+func Status(err error) *status.Status {
+	return defaultConverter.Status(err)
+}
+
+//Error method converts gRPC status to error instance.
+//The error instance has error code from ErrorInfo.Reason, context from ErrorInfo.Metadata,
+//kind mapped to the status code, and cause chain rebuilt from DebugInfo.
+//The error instance is compatible with status.FromError and status.Code functions.
+//If st is OK status, Error method returns nil.
+func (cv *Converter) Error(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+	se := &statusError{st: st}
+	opts := []errs.ErrorContextFunc{errs.WithContext("grpc_code", st.Code().String())}
+	if kind, ok := cv.reverse[st.Code()]; ok {
+		opts = append(opts, errs.WithKind(kind))
+	}
+	for _, d := range st.Details() {
+		switch info := d.(type) {
+		case *errdetails.ErrorInfo:
+			if info.GetDomain() != cv.domain {
+				continue
+			}
+			if reason := info.GetReason(); len(reason) > 0 && reason != st.Code().String() {
+				opts = append(opts, errs.WithCode(errs.Code(reason)))
+			}
+			for k, v := range info.GetMetadata() {
+				opts = append(opts, errs.WithContext(k, v))
+			}
+		case *errdetails.DebugInfo:
+			if cause, e := errs.DecodeJSON([]byte(info.GetDetail())); e == nil {
+				se.cause = cause
+			}
+		}
+	}
+	return errs.Wrap(se, opts...)
+}
+
+//FromStatus function converts gRPC status to error instance by default Converter.
+func FromStatus(st *status.Status) error {
+	return defaultConverter.Error(st)
+}
+
+//statusError type is error instance of gRPC status with cause chain.
+type statusError struct {
+	st    *status.Status
+	cause error
+}
+
+//Error method returns message of gRPC status.
+func (e *statusError) Error() string {
+	return e.st.Message()
+}
+
+//GRPCStatus method returns gRPC status.
+//This method is used in status.FromError function.
+func (e *statusError) GRPCStatus() *status.Status {
+	return e.st
+}
+
+//Unwrap method returns cause chain rebuilt from DebugInfo.
+func (e *statusError) Unwrap() error {
+	return e.cause
+}
+
+//metadataOf method returns context values of whitelisted keys in err's tree as metadata of ErrorInfo.
+//Context values of outer error instance take precedence. (see errs.Fields function)
+//Context values are redacted by errs.Redacted type and global policy of redaction.
+func (cv *Converter) metadataOf(err error) map[string]string {
+	if len(cv.metadata) == 0 {
+		return nil
+	}
+	fields := errs.Fields(err)
+	md := map[string]string{}
+	for _, k := range cv.metadata {
+		if v, ok := fields[k]; ok {
+			md[k] = fmt.Sprint(errs.RedactValue(k, v))
+		}
+	}
+	if len(md) == 0 {
+		return nil
+	}
+	return md
+}
+
+//stackEntries returns stack trace of the outermost *errs.Error instance.
+func stackEntries(err error) []string {
+	var e *errs.Error
+	if !errs.As(err, &e) {
+		return nil
+	}
+	entries := []string{}
+	for _, f := range e.StackTrace() {
+		entries = append(entries, fmt.Sprintf("%s %s:%d", f.Function, f.File, f.Line))
+	}
+	return entries
+}
+
+//UnaryServerInterceptor method returns grpc.UnaryServerInterceptor that converts errors returned by handlers to gRPC status.
+func (cv *Converter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, cv.Status(err).Err()
+		}
+		return resp, nil
+	}
+}
+
+//StreamServerInterceptor method returns grpc.StreamServerInterceptor that converts errors returned by handlers to gRPC status.
+func (cv *Converter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return cv.Status(err).Err()
+		}
+		return nil
+	}
+}
+
+//UnaryClientInterceptor method returns grpc.UnaryClientInterceptor that converts gRPC status to error instances.
+func (cv *Converter) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return cv.fromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+//StreamClientInterceptor method returns grpc.StreamClientInterceptor that converts gRPC status to error instances.
+func (cv *Converter) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, cv.fromError(err)
+		}
+		return &clientStream{ClientStream: cs, cv: cv}, nil
+	}
+}
+
+//fromError converts gRPC status error to error instance.
+func (cv *Converter) fromError(err error) error {
+	if err == nil || errs.Is(err, io.EOF) {
+		return err
+	}
+	return cv.Error(status.Convert(err))
+}
+
+//clientStream type is grpc.ClientStream that converts gRPC status to error instances.
+type clientStream struct {
+	grpc.ClientStream
+	cv *Converter
+}
+
+//SendMsg method sends a message, and converts gRPC status to error instance.
+func (s *clientStream) SendMsg(m interface{}) error {
+	return s.cv.fromError(s.ClientStream.SendMsg(m))
+}
+
+//RecvMsg method receives a message, and converts gRPC status to error instance.
+func (s *clientStream) RecvMsg(m interface{}) error {
+	return s.cv.fromError(s.ClientStream.RecvMsg(m))
+}
+
+//UnaryServerInterceptor function returns grpc.UnaryServerInterceptor by default Converter.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return defaultConverter.UnaryServerInterceptor()
+}
+
+//StreamServerInterceptor function returns grpc.StreamServerInterceptor by default Converter.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return defaultConverter.StreamServerInterceptor()
+}
+
+//UnaryClientInterceptor function returns grpc.UnaryClientInterceptor by default Converter.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return defaultConverter.UnaryClientInterceptor()
+}
+
+//StreamClientInterceptor function returns grpc.StreamClientInterceptor by default Converter.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return defaultConverter.StreamClientInterceptor()
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,311 @@
+package errsgrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	codeNotFound = errs.RegisterCode("GRPC-404", "resource not found", errs.SeverityError, "")
+	kindQuota    = errs.NewKind("GRPCQuota", errs.Unavailable)
+)
+
+func TestStatus(t *testing.T) {
+	cv := NewConverter(WithCode(codeNotFound, codes.NotFound), WithMetadata("id", "name"), WithDebugInfo(true))
+	testCases := []struct {
+		err      error
+		code     codes.Code
+		msg      string
+		reason   string
+		metadata map[string]string
+	}{
+		{err: nil, code: codes.OK, msg: ""},
+		{err: status.Error(codes.InvalidArgument, "invalid"), code: codes.InvalidArgument, msg: "invalid"},
+		{err: os.ErrInvalid, code: codes.Unknown, msg: errs.DefaultPublicMessage, reason: "Unknown"},
+		{err: errs.New("query failed", errs.WithCause(os.ErrInvalid), errs.WithPublicMessage("try again later")), code: codes.Unknown, msg: "try again later", reason: "Unknown"},
+		{err: errs.Wrap(errs.NewCode(codeNotFound, errs.WithCause(os.ErrNotExist), errs.WithContext("id", 1)), errs.WithContext("id", 2)), code: codes.NotFound, msg: "resource not found", reason: "GRPC-404", metadata: map[string]string{"id": "2"}},
+	}
+
+	for _, tc := range testCases {
+		st := cv.Status(tc.err)
+		if st.Code() != tc.code {
+			t.Errorf("Status(\"%v\").Code() is %v, want %v", tc.err, st.Code(), tc.code)
+		}
+		if st.Message() != tc.msg {
+			t.Errorf("Status(\"%v\").Message() is %v, want %v", tc.err, st.Message(), tc.msg)
+		}
+		if len(tc.reason) == 0 {
+			continue
+		}
+		var info *errdetails.ErrorInfo
+		var debug *errdetails.DebugInfo
+		for _, d := range st.Details() {
+			switch x := d.(type) {
+			case *errdetails.ErrorInfo:
+				info = x
+			case *errdetails.DebugInfo:
+				debug = x
+			}
+		}
+		if info == nil || debug == nil {
+			t.Errorf("Status(\"%v\").Details() is %v, want ErrorInfo and DebugInfo", tc.err, st.Details())
+			continue
+		}
+		if info.GetReason() != tc.reason || info.GetDomain() != DefaultDomain {
+			t.Errorf("ErrorInfo of Status(\"%v\") is %v, want reason %v", tc.err, info, tc.reason)
+		}
+		if len(info.GetMetadata()) != len(tc.metadata) {
+			t.Errorf("ErrorInfo.Metadata of Status(\"%v\") is %v, want %v", tc.err, info.GetMetadata(), tc.metadata)
+		}
+		for k, v := range tc.metadata {
+			if info.GetMetadata()[k] != v {
+				t.Errorf("ErrorInfo.Metadata[%q] of Status(\"%v\") is %v, want %v", k, tc.err, info.GetMetadata()[k], v)
+			}
+		}
+		if debug.GetDetail() != errs.EncodeJSON(tc.err) {
+			t.Errorf("DebugInfo.Detail of Status(\"%v\") is %v, want %v", tc.err, debug.GetDetail(), errs.EncodeJSON(tc.err))
+		}
+	}
+}
+
+func TestStatusCycle(t *testing.T) {
+	inner := errs.New("inner").(*errs.Error)
+	outer := errs.Wrap(inner, errs.WithCause(status.Error(codes.Unavailable, "unavailable"))).(*errs.Error)
+	inner.Cause = outer //cycle built by assignment
+	if c := Status(outer).Code(); c != codes.Unavailable {
+		t.Errorf("Status(\"%v\").Code() is %v, want %v", outer, c, codes.Unavailable)
+	}
+	inner.Cause = errs.Wrap(inner)
+	if c := Status(inner).Code(); c != codes.Unknown {
+		t.Errorf("Status(\"%v\").Code() is %v, want %v", inner, c, codes.Unknown)
+	}
+}
+
+func TestStatusWithKind(t *testing.T) {
+	cv := NewConverter(WithCode(codeNotFound, codes.NotFound), WithStandardKinds(), WithKind(kindQuota, codes.ResourceExhausted))
+	testCases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: errs.Wrap(context.DeadlineExceeded), code: codes.DeadlineExceeded},
+		{err: errs.Wrap(os.ErrNotExist), code: codes.NotFound},
+		{err: errs.New("quota", errs.WithKind(kindQuota)), code: codes.ResourceExhausted},
+		{err: errs.New("busy", errs.WithKind(errs.NewKind("GRPCBusy", errs.Unavailable))), code: codes.Unavailable},
+		{err: errs.NewCode(codeNotFound, errs.WithKind(errs.Conflict)), code: codes.NotFound},
+		{err: errs.New("unknown"), code: codes.Unknown},
+	}
+
+	for _, tc := range testCases {
+		if c := cv.Status(tc.err).Code(); c != tc.code {
+			t.Errorf("Status(\"%v\").Code() is %v, want %v", tc.err, c, tc.code)
+		}
+	}
+
+	err := cv.Error(status.New(codes.DeadlineExceeded, "deadline exceeded"))
+	if !errs.Is(err, errs.Timeout) {
+		t.Errorf("Is(Error(), Timeout) is false, want true")
+	}
+	if kind := errs.KindOf(cv.Error(status.New(codes.Aborted, "aborted"))); kind != nil {
+		t.Errorf("KindOf(Error()) is %v, want <nil>", kind)
+	}
+}
+
+func TestStatusWithoutDebugInfo(t *testing.T) {
+	err := errs.New("query failed", errs.WithCause(os.ErrInvalid), errs.WithContext("id", 1))
+	st := NewConverter(WithFallback(codes.Internal), WithDomain("example.com")).Status(err)
+	if st.Code() != codes.Internal {
+		t.Errorf("Status().Code() is %v, want %v", st.Code(), codes.Internal)
+	}
+	for _, d := range st.Details() {
+		switch x := d.(type) {
+		case *errdetails.DebugInfo:
+			t.Errorf("Status().Details() has DebugInfo %v", x)
+		case *errdetails.ErrorInfo:
+			if x.GetDomain() != "example.com" {
+				t.Errorf("ErrorInfo.Domain is %v, want %v", x.GetDomain(), "example.com")
+			}
+			if len(x.GetMetadata()) > 0 {
+				t.Errorf("ErrorInfo.Metadata is %v, want empty", x.GetMetadata())
+			}
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	cv := NewConverter(WithCode(codeNotFound, codes.NotFound), WithDebugInfo(true))
+	src := errs.NewCode(codeNotFound, errs.WithCause(os.ErrNotExist), errs.WithContext("id", 1))
+	testCases := []struct {
+		st    *status.Status
+		isNil bool
+		code  codes.Code
+		msg   string
+		ecode errs.Code
+		cause string
+	}{
+		{st: nil, isNil: true},
+		{st: status.New(codes.OK, ""), isNil: true},
+		{st: status.New(codes.Internal, "internal error"), code: codes.Internal, msg: "internal error", cause: "internal error"},
+		{st: cv.Status(src), code: codes.NotFound, msg: "resource not found", ecode: codeNotFound, cause: "file does not exist"},
+	}
+
+	for _, tc := range testCases {
+		err := cv.Error(tc.st)
+		if tc.isNil {
+			if err != nil {
+				t.Errorf("Error(%v) is \"%v\", want <nil>", tc.st, err)
+			}
+			continue
+		}
+		if err.Error() != tc.msg {
+			t.Errorf("Error(%v) is \"%v\", want \"%v\"", tc.st, err, tc.msg)
+		}
+		if c := status.Code(err); c != tc.code {
+			t.Errorf("status.Code(Error(%v)) is %v, want %v", tc.st, c, tc.code)
+		}
+		if c := errs.CodeOf(err); c != tc.ecode {
+			t.Errorf("errs.CodeOf(Error(%v)) is %q, want %q", tc.st, c, tc.ecode)
+		}
+		if c := errs.Cause(err); c.Error() != tc.cause {
+			t.Errorf("errs.Cause(Error(%v)) is \"%v\", want \"%v\"", tc.st, c, tc.cause)
+		}
+	}
+}
+
+const (
+	testServiceName = "errsgrpc.test.TestService"
+	testUnaryMethod = "/" + testServiceName + "/Fail"
+	testStreamName  = "FailStream"
+)
+
+var errTestService = errs.NewCode(codeNotFound, errs.WithCause(os.ErrNotExist), errs.WithContext("id", "abc"))
+
+var testServiceDesc = grpc.ServiceDesc{
+	ServiceName: testServiceName,
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Fail",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := &emptypb.Empty{}
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return nil, errTestService
+				}
+				if interceptor == nil {
+					return handler(ctx, in)
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: testUnaryMethod}, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    testStreamName,
+			ServerStreams: true,
+			Handler: func(srv interface{}, stream grpc.ServerStream) error {
+				return errTestService
+			},
+		},
+	},
+}
+
+func newTestConn(t *testing.T, cv *Converter) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(cv.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(cv.StreamServerInterceptor()),
+	)
+	srv.RegisterService(&testServiceDesc, struct{}{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(cv.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(cv.StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() is \"%v\", want <nil>", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func checkInterceptedError(t *testing.T, err error) {
+	t.Helper()
+	if c := status.Code(err); c != codes.NotFound {
+		t.Errorf("status.Code() is %v, want %v", c, codes.NotFound)
+	}
+	if c := errs.CodeOf(err); c != codeNotFound {
+		t.Errorf("errs.CodeOf() is %q, want %q", c, codeNotFound)
+	}
+	if !errors.Is(err, errs.Cause(err)) || errs.Cause(err).Error() != os.ErrNotExist.Error() {
+		t.Errorf("errs.Cause() is \"%v\", want \"%v\"", errs.Cause(err), os.ErrNotExist)
+	}
+	var e *errs.Error
+	if !errors.As(err, &e) || e.Context["id"] != "abc" {
+		t.Errorf("context of \"%v\" is %v, want id=abc", err, e)
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	conn := newTestConn(t, NewConverter(WithCode(codeNotFound, codes.NotFound), WithMetadata("id"), WithDebugInfo(true)))
+	err := conn.Invoke(context.Background(), testUnaryMethod, &emptypb.Empty{}, &emptypb.Empty{})
+	if err == nil {
+		t.Fatal("Invoke() is <nil>, want error")
+	}
+	checkInterceptedError(t, err)
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	conn := newTestConn(t, NewConverter(WithCode(codeNotFound, codes.NotFound), WithMetadata("id"), WithDebugInfo(true)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cs, err := conn.NewStream(ctx, &testServiceDesc.Streams[0], "/"+testServiceName+"/"+testStreamName)
+	if err != nil {
+		t.Fatalf("NewStream() is \"%v\", want <nil>", err)
+	}
+	if err := cs.SendMsg(&emptypb.Empty{}); err != nil {
+		t.Fatalf("SendMsg() is \"%v\", want <nil>", err)
+	}
+	if err := cs.CloseSend(); err != nil {
+		t.Fatalf("CloseSend() is \"%v\", want <nil>", err)
+	}
+	err = cs.RecvMsg(&emptypb.Empty{})
+	if err == nil {
+		t.Fatal("RecvMsg() is <nil>, want error")
+	}
+	checkInterceptedError(t, err)
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
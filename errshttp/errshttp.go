@@ -0,0 +1,375 @@
+// Package errshttp renders error instances as problem details (RFC 9457, formerly RFC 7807) for HTTP APIs.
+package errshttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/spiegel-im-spiegel/errs"
+)
+
+const (
+	//ContentType is media type of problem details JSON object.
+	ContentType = "application/problem+json"
+	//DefaultType is default value of "type" member.
+	DefaultType = "about:blank"
+)
+
+//Problem type is problem details object.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+var _ json.Marshaler = (*Problem)(nil)   //Problem type is compatible with json.Marshaler interface
+var _ json.Unmarshaler = (*Problem)(nil) //Problem type is compatible with json.Unmarshaler interface
+
+//standardMembers is the names of standard members of problem details object.
+var standardMembers = map[string]bool{"type": true, "title": true, "status": true, "detail": true, "instance": true}
+
+//MarshalJSON method returns problem details JSON object.
+//Extension members are placed in the same level as standard members.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+	members := map[string]interface{}{}
+	for k, v := range p.Extensions {
+		if !standardMembers[k] {
+			members[k] = v
+		}
+	}
+	typ := p.Type
+	if len(typ) == 0 {
+		typ = DefaultType
+	}
+	members["type"] = typ
+	if len(p.Title) > 0 {
+		members["title"] = p.Title
+	}
+	if p.Status > 0 {
+		members["status"] = p.Status
+	}
+	if len(p.Detail) > 0 {
+		members["detail"] = p.Detail
+	}
+	if len(p.Instance) > 0 {
+		members["instance"] = p.Instance
+	}
+	return json.Marshal(members)
+}
+
+//UnmarshalJSON method parses problem details JSON object.
+//Members other than standard members are stored in Extensions.
+func (p *Problem) UnmarshalJSON(b []byte) error {
+	var std struct {
+		Type     string `json:"type"`
+		Title    string `json:"title"`
+		Status   int    `json:"status"`
+		Detail   string `json:"detail"`
+		Instance string `json:"instance"`
+	}
+	if err := json.Unmarshal(b, &std); err != nil {
+		return err
+	}
+	members := map[string]interface{}{}
+	if err := json.Unmarshal(b, &members); err != nil {
+		return err
+	}
+	*p = Problem{Type: std.Type, Title: std.Title, Status: std.Status, Detail: std.Detail, Instance: std.Instance}
+	if len(p.Type) == 0 {
+		p.Type = DefaultType
+	}
+	for k, v := range members {
+		if standardMembers[k] {
+			continue
+		}
+		if p.Extensions == nil {
+			p.Extensions = map[string]interface{}{}
+		}
+		p.Extensions[k] = v
+	}
+	return nil
+}
+
+//Mapping type is problem type for error code or kind.
+type Mapping struct {
+	Status int
+	Type   string
+	Title  string
+}
+
+//Renderer type renders error instances as problem details.
+type Renderer struct {
+	codes      map[errs.Code]Mapping
+	kinds      map[*errs.Kind]Mapping
+	fallback   Mapping
+	extensions []string
+	debug      bool
+}
+
+//Option type is self-referential function type for NewRenderer function. (functional options pattern)
+type Option func(*Renderer)
+
+//WithCode function returns Option function value.
+//This function is used in NewRenderer function that represents mapping of error code to problem type.
+func WithCode(code errs.Code, m Mapping) Option {
+	return func(r *Renderer) {
+		r.codes[code] = m
+	}
+}
+
+//WithKind function returns Option function value.
+//This function is used in NewRenderer function that represents mapping of error kind (and its descendant kinds) to problem type.
+//Mapping of error code takes precedence over mapping of error kind.
+func WithKind(kind *errs.Kind, m Mapping) Option {
+	return func(r *Renderer) {
+		r.kinds[kind] = m
+	}
+}
+
+//StandardKinds is mapping of predefined error kinds to HTTP status codes.
+var StandardKinds = map[*errs.Kind]int{
+	errs.NotFound:    http.StatusNotFound,
+	errs.Permission:  http.StatusForbidden,
+	errs.Invalid:     http.StatusBadRequest,
+	errs.Conflict:    http.StatusConflict,
+	errs.Unavailable: http.StatusServiceUnavailable,
+	errs.Timeout:     http.StatusGatewayTimeout,
+	errs.Internal:    http.StatusInternalServerError,
+}
+
+//WithStandardKinds function returns Option function value.
+//This function is used in NewRenderer function that represents mapping of predefined error kinds by StandardKinds.
+//Note that standard-library errors are classified automatically (e.g. fs.ErrNotExist is NotFound kind).
+func WithStandardKinds() Option {
+	return func(r *Renderer) {
+		for kind, status := range StandardKinds {
+			r.kinds[kind] = Mapping{Status: status}
+		}
+	}
+}
+
+//WithFallback function returns Option function value.
+//This function is used in NewRenderer function that represents problem type for errors without mapped error code and kind.
+//(Default is status 500 "Internal Server Error".)
+func WithFallback(m Mapping) Option {
+	return func(r *Renderer) {
+		r.fallback = m
+	}
+}
+
+//WithExtensions function returns Option function value.
+//This function is used in NewRenderer function that represents whitelist of context keys output as extension members.
+func WithExtensions(keys ...string) Option {
+	return func(r *Renderer) {
+		r.extensions = append(r.extensions, keys...)
+	}
+}
+
+//WithDebug function returns Option function value.
+//This function is used in NewRenderer function that represents debug mode.
+//In debug mode, the whole error message is output as "detail" member and the error tree as "debug" extension member.
+//Do not use debug mode in production, because internal cause messages leak.
+func WithDebug(debug bool) Option {
+	return func(r *Renderer) {
+		r.debug = debug
+	}
+}
+
+//NewRenderer function returns Renderer instance.
+func NewRenderer(opts ...Option) *Renderer {
+	r := &Renderer{
+		codes:    map[errs.Code]Mapping{},
+		kinds:    map[*errs.Kind]Mapping{},
+		fallback: Mapping{Status: http.StatusInternalServerError},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+var defaultRenderer = NewRenderer()
+
+//kindMapping returns mapping of kind or its nearest ancestor kind.
+func (r *Renderer) kindMapping(kind *errs.Kind) (Mapping, bool) {
+	for k := kind; k != nil; k = k.Parent() {
+		if m, ok := r.kinds[k]; ok {
+			return m, true
+		}
+	}
+	return Mapping{}, false
+}
+
+//Problem method returns problem details of error instance.
+//The "detail" member is user-facing message (public message or default message of error code).
+//Internal cause messages are not contained in problem details except for debug mode.
+func (r *Renderer) Problem(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+	code := errs.CodeOf(err)
+	m, ok := r.codes[code]
+	if !ok {
+		m, ok = r.kindMapping(errs.KindOf(err))
+	}
+	if !ok {
+		m = r.fallback
+	}
+	p := &Problem{Type: m.Type, Title: m.Title, Status: m.Status}
+	if p.Status == 0 {
+		p.Status = http.StatusInternalServerError
+	}
+	if len(p.Type) == 0 {
+		p.Type = DefaultType
+	}
+	if len(p.Title) == 0 {
+		p.Title = http.StatusText(p.Status)
+	}
+	//generic message is not used because "title" member is the generic message of the status
+	if msg, ok := errs.LookupPublicMessage(err); ok {
+		p.Detail = msg
+	}
+	if len(code) > 0 {
+		p.setExtension("code", string(code))
+	}
+	fields := errs.Fields(err)
+	for _, key := range r.extensions {
+		if v, ok := fields[key]; ok {
+			p.setExtension(key, errs.RedactValue(key, v))
+		}
+	}
+	if r.debug {
+		p.Detail = err.Error()
+		p.setExtension("debug", json.RawMessage(errs.EncodeJSON(err)))
+	}
+	return p
+}
+
+//setExtension method sets extension member.
+func (p *Problem) setExtension(name string, value interface{}) {
+	if p.Extensions == nil {
+		p.Extensions = map[string]interface{}{}
+	}
+	p.Extensions[name] = value
+}
+
+//Write method writes problem details of error instance as HTTP response.
+func (r *Renderer) Write(w http.ResponseWriter, err error) error {
+	p := r.Problem(err)
+	if p == nil {
+		return nil
+	}
+	b, e := json.Marshal(p)
+	if e != nil {
+		return errs.Wrap(e)
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	if _, e := w.Write(b); e != nil {
+		return errs.Wrap(e)
+	}
+	return nil
+}
+
+//Write function writes problem details of error instance as HTTP response by default Renderer.
+func Write(w http.ResponseWriter, err error) error {
+	return defaultRenderer.Write(w, err)
+}
+
+//ErrNotProblem is returned by ParseResponse function if the response is not problem details.
+var ErrNotProblem = errors.New("response is not problem details")
+
+//ParseResponse function parses problem details in HTTP response and returns *errs.Error instance.
+func ParseResponse(resp *http.Response) (*errs.Error, error) {
+	if resp == nil {
+		return nil, errs.Wrap(ErrNotProblem)
+	}
+	mt, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mt != ContentType {
+		return nil, errs.Wrap(ErrNotProblem, errs.WithContext("content_type", resp.Header.Get("Content-Type")), errs.WithContext("status", resp.StatusCode))
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	e, err := Parse(b)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := e.Context["status"]; !ok {
+		_ = e.SetContext("status", resp.StatusCode)
+	}
+	return e, nil
+}
+
+//Parse function parses problem details JSON object and returns *errs.Error instance.
+func Parse(b []byte) (*errs.Error, error) {
+	p := &Problem{}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return FromProblem(p), nil
+}
+
+//FromProblem function returns *errs.Error instance converted from problem details.
+//The message of the error instance is "detail" member (or "title" member), and other members are stored in the context.
+//"code" extension member is converted to error code.
+func FromProblem(p *Problem) *errs.Error {
+	if p == nil {
+		return nil
+	}
+	msg := p.Detail
+	if len(msg) == 0 {
+		msg = p.Title
+	}
+	if len(msg) == 0 {
+		msg = fmt.Sprintf("HTTP status %d", p.Status)
+	}
+	opts := []errs.ErrorContextFunc{
+		errs.WithContext("type", p.Type),
+		errs.WithContext("status", p.Status),
+	}
+	if len(p.Title) > 0 {
+		opts = append(opts, errs.WithContext("title", p.Title))
+	}
+	if len(p.Instance) > 0 {
+		opts = append(opts, errs.WithContext("instance", p.Instance))
+	}
+	for k, v := range p.Extensions {
+		if k == "code" {
+			if code, ok := v.(string); ok {
+				opts = append(opts, errs.WithCode(errs.Code(code)))
+				continue
+			}
+		}
+		opts = append(opts, errs.WithContext(k, v))
+	}
+	e, _ := errs.New(msg, opts...).(*errs.Error)
+	return e
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,159 @@
+package errshttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/errs"
+)
+
+var (
+	codeNotFound = errs.RegisterCode("HTTP-404", "resource not found", errs.SeverityError, "https://example.com/errors/HTTP-404")
+	kindQuota    = errs.NewKind("HTTPQuota", errs.Unavailable)
+)
+
+func TestProblem(t *testing.T) {
+	_, pathErr := os.Open("not-exist.txt")
+	renderer := NewRenderer(
+		WithCode(codeNotFound, Mapping{Status: http.StatusNotFound, Type: "https://example.com/problems/not-found"}),
+		WithExtensions("id", "missing"),
+	)
+	testCases := []struct {
+		renderer *Renderer
+		err      error
+		status   int
+		json     string
+	}{
+		{renderer: renderer, err: errs.New("internal error", errs.WithCause(pathErr), errs.WithContext("id", 1), errs.WithContext("path", "not-exist.txt")), status: 500, json: `{"id":1,"status":500,"title":"Internal Server Error","type":"about:blank"}`},
+		{renderer: renderer, err: errs.Wrap(errs.NewCode(codeNotFound, errs.WithCause(pathErr), errs.WithContext("id", 2)), errs.WithContext("id", 1)), status: 404, json: `{"code":"HTTP-404","detail":"resource not found","id":1,"status":404,"title":"Not Found","type":"https://example.com/problems/not-found"}`},
+		{renderer: renderer, err: errs.New("internal error", errs.WithSecret("id", 1)), status: 500, json: `{"id":"[REDACTED]","status":500,"title":"Internal Server Error","type":"about:blank"}`},
+		{renderer: NewRenderer(WithFallback(Mapping{Status: http.StatusBadRequest, Title: "Bad Request Data"})), err: errs.New("bad request"), status: 400, json: `{"status":400,"title":"Bad Request Data","type":"about:blank"}`},
+		{renderer: NewRenderer(WithStandardKinds()), err: errs.Wrap(pathErr), status: 404, json: `{"status":404,"title":"Not Found","type":"about:blank"}`},
+		{renderer: NewRenderer(WithStandardKinds(), WithKind(kindQuota, Mapping{Status: http.StatusTooManyRequests})), err: errs.New("too many", errs.WithKind(kindQuota)), status: 429, json: `{"status":429,"title":"Too Many Requests","type":"about:blank"}`},
+		{renderer: NewRenderer(WithStandardKinds()), err: errs.New("quota", errs.WithKind(kindQuota)), status: 503, json: `{"status":503,"title":"Service Unavailable","type":"about:blank"}`},
+		{renderer: NewRenderer(WithCode(codeNotFound, Mapping{Status: http.StatusGone}), WithStandardKinds()), err: errs.NewCode(codeNotFound, errs.WithKind(errs.NotFound)), status: 410, json: `{"code":"HTTP-404","detail":"resource not found","status":410,"title":"Gone","type":"about:blank"}`},
+		{renderer: renderer, err: errs.New("query failed", errs.WithCause(pathErr), errs.WithPublicMessage("try again later")), status: 500, json: `{"detail":"try again later","status":500,"title":"Internal Server Error","type":"about:blank"}`},
+		{renderer: renderer, err: errs.Wrap(errs.NewCode(codeNotFound, errs.WithCause(pathErr)), errs.WithPublicMessage("user not found")), status: 404, json: `{"code":"HTTP-404","detail":"user not found","status":404,"title":"Not Found","type":"https://example.com/problems/not-found"}`},
+		{renderer: NewRenderer(WithDebug(true)), err: os.ErrInvalid, status: 500, json: `{"debug":{"Type":"*errors.errorString","Msg":"invalid argument"},"detail":"invalid argument","status":500,"title":"Internal Server Error","type":"about:blank"}`},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		if err := tc.renderer.Write(w, tc.err); err != nil {
+			t.Errorf("Write(\"%v\") is \"%v\", want <nil>", tc.err, err)
+			continue
+		}
+		if w.Code != tc.status {
+			t.Errorf("status of Write(\"%v\") is %v, want %v", tc.err, w.Code, tc.status)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != ContentType {
+			t.Errorf("Content-Type of Write(\"%v\") is %v, want %v", tc.err, ct, ContentType)
+		}
+		if str := w.Body.String(); str != tc.json {
+			t.Errorf("body of Write(\"%v\") is %v, want %v", tc.err, str, tc.json)
+		}
+		if strings.Contains(w.Body.String(), "no such file or directory") {
+			t.Errorf("body of Write(\"%v\") leaks cause message: %v", tc.err, w.Body.String())
+		}
+	}
+}
+
+func TestWriteNil(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := Write(w, nil); err != nil {
+		t.Errorf("Write(nil) is \"%v\", want <nil>", err)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("body of Write(nil) is %v, want empty", w.Body.String())
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	renderer := NewRenderer(
+		WithCode(codeNotFound, Mapping{Status: http.StatusNotFound}),
+		WithExtensions("id"),
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/text" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		_ = renderer.Write(w, errs.NewCode(codeNotFound, errs.WithContext("id", "abc")))
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/problem")
+	if err != nil {
+		t.Fatalf("http.Get() is \"%v\", want <nil>", err)
+	}
+	defer resp.Body.Close()
+	e, err := ParseResponse(resp)
+	if err != nil {
+		t.Fatalf("ParseResponse() is \"%v\", want <nil>", err)
+	}
+	if str := e.Error(); str != "resource not found" {
+		t.Errorf("ParseResponse() is \"%v\", want \"%v\"", str, "resource not found")
+	}
+	if code := errs.CodeOf(e); code != codeNotFound {
+		t.Errorf("CodeOf(ParseResponse()) is %q, want %q", code, codeNotFound)
+	}
+	for k, v := range map[string]interface{}{"id": "abc", "status": 404, "title": "Not Found", "type": "about:blank"} {
+		if e.Context[k] != v {
+			t.Errorf("ParseResponse().Context[%q] is %v, want %v", k, e.Context[k], v)
+		}
+	}
+
+	resp2, err := http.Get(srv.URL + "/text")
+	if err != nil {
+		t.Fatalf("http.Get() is \"%v\", want <nil>", err)
+	}
+	defer resp2.Body.Close()
+	if _, err := ParseResponse(resp2); !errs.Is(err, ErrNotProblem) {
+		t.Errorf("ParseResponse() is \"%v\", want \"%v\"", err, ErrNotProblem)
+	}
+}
+
+func TestProblemJSON(t *testing.T) {
+	testCases := []struct {
+		src  string
+		json string
+	}{
+		{src: `{}`, json: `{"type":"about:blank"}`},
+		{src: `{"type":"https://example.com/problems/out-of-credit","title":"You do not have enough credit.","status":403,"detail":"Your current balance is 30, but that costs 50.","instance":"/account/12345/msgs/abc","balance":30}`, json: `{"balance":30,"detail":"Your current balance is 30, but that costs 50.","instance":"/account/12345/msgs/abc","status":403,"title":"You do not have enough credit.","type":"https://example.com/problems/out-of-credit"}`},
+	}
+
+	for _, tc := range testCases {
+		p := &Problem{}
+		if err := json.Unmarshal([]byte(tc.src), p); err != nil {
+			t.Errorf("json.Unmarshal(%v) is \"%v\", want <nil>", tc.src, err)
+			continue
+		}
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Errorf("json.Marshal(%v) is \"%v\", want <nil>", tc.src, err)
+			continue
+		}
+		if string(b) != tc.json {
+			t.Errorf("json.Marshal(%v) is %s, want %v", tc.src, b, tc.json)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
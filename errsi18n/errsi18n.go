@@ -0,0 +1,125 @@
+// Package errsi18n renders localized messages of error instances by message IDs (see errs.WithMessageID function).
+package errsi18n
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+//Catalog interface is catalog of localized messages.
+//Message method returns false if the message of ID is not found for the language (and its parent languages).
+type Catalog interface {
+	Message(tag language.Tag, id string, args ...interface{}) (string, bool)
+}
+
+//MapCatalog type is simple Catalog of format strings (for fmt.Sprintf function) by language and message ID.
+//If the message is not found for the language, parent languages (e.g. "en" for "en-US") are searched.
+type MapCatalog map[language.Tag]map[string]string
+
+var _ Catalog = MapCatalog{} //MapCatalog type is compatible with Catalog interface
+
+//Message method returns localized message of ID with arguments.
+func (c MapCatalog) Message(tag language.Tag, id string, args ...interface{}) (string, bool) {
+	for t := tag; ; t = t.Parent() {
+		if format, ok := c[t][id]; ok {
+			if len(args) == 0 {
+				return format, true
+			}
+			return fmt.Sprintf(format, args...), true
+		}
+		if t == language.Und {
+			return "", false
+		}
+	}
+}
+
+//textCatalog type is Catalog adapter of golang.org/x/text/message/catalog package. (internal)
+type textCatalog struct {
+	catalog catalog.Catalog
+}
+
+//NewTextCatalog function returns Catalog adapter of catalog.Catalog instance (e.g. catalog.Builder).
+//Messages are formatted by message.Printer, so plural and gender selectors of golang.org/x/text/message package are available.
+func NewTextCatalog(c catalog.Catalog) Catalog {
+	return &textCatalog{catalog: c}
+}
+
+//Message method returns localized message of ID with arguments.
+func (c *textCatalog) Message(tag language.Tag, id string, args ...interface{}) (string, bool) {
+	if c == nil || c.catalog == nil {
+		return "", false
+	}
+	if err := c.catalog.Context(tag, nopRenderer{}).Execute(id); err != nil {
+		return "", false
+	}
+	return message.NewPrinter(tag, message.Catalog(c.catalog)).Sprintf(id, args...), true
+}
+
+//nopRenderer type is renderer that discards messages, for checking existence of messages. (internal)
+type nopRenderer struct{}
+
+func (nopRenderer) Render(string)       {}
+func (nopRenderer) Arg(int) interface{} { return nil }
+
+//Localizer type renders localized messages of error instances.
+type Localizer struct {
+	catalog Catalog
+}
+
+//NewLocalizer function returns Localizer instance with Catalog.
+func NewLocalizer(c Catalog) *Localizer {
+	return &Localizer{catalog: c}
+}
+
+//Localize method returns error message of err localized for the language.
+//Messages of Error instances in the chain are replaced by messages of their message IDs in Catalog.
+//Messages not found in Catalog remain the same as Error method (English or the default locale).
+func (l *Localizer) Localize(err error, tag language.Tag) string {
+	if l == nil || l.catalog == nil {
+		return errs.Translate(err, nil)
+	}
+	return errs.Translate(err, func(id string, args []interface{}) (string, bool) {
+		return l.catalog.Message(tag, id, args...)
+	})
+}
+
+var (
+	defaultMutex     sync.RWMutex
+	defaultLocalizer = NewLocalizer(NewTextCatalog(message.DefaultCatalog))
+)
+
+//SetCatalog function sets Catalog of default Localizer.
+//(Default is message.DefaultCatalog of golang.org/x/text/message package, which is set by message.SetString function and so on.)
+func SetCatalog(c Catalog) {
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+	defaultLocalizer = NewLocalizer(c)
+}
+
+//Localize function returns error message of err localized for the language by default Localizer.
+func Localize(err error, tag language.Tag) string {
+	defaultMutex.RLock()
+	l := defaultLocalizer
+	defaultMutex.RUnlock()
+	return l.Localize(err, tag)
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
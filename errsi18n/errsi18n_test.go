@@ -0,0 +1,97 @@
+package errsi18n
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+func newErr() error {
+	return errs.New(
+		"cannot save user 42",
+		errs.WithMessageID("user.save", 42),
+		errs.WithCause(errs.Wrap(os.ErrNotExist, errs.WithMessageID("file.notfound", "users.json"))),
+	)
+}
+
+func TestMapCatalog(t *testing.T) {
+	l := NewLocalizer(MapCatalog{
+		language.English:  {"user.save": "cannot save user %d", "file.notfound": "file %s not found"},
+		language.Japanese: {"user.save": "ユーザー %d を保存できません", "file.notfound": "ファイル %s が見つかりません"},
+		language.German:   {"user.save": "Benutzer %d kann nicht gespeichert werden"},
+	})
+	testCases := []struct {
+		tag language.Tag
+		msg string
+	}{
+		{tag: language.English, msg: "cannot save user 42: file users.json not found"},
+		{tag: language.Japanese, msg: "ユーザー 42 を保存できません: ファイル users.json が見つかりません"},
+		{tag: language.MustParse("de-AT"), msg: "Benutzer 42 kann nicht gespeichert werden: file does not exist"},
+		{tag: language.French, msg: "cannot save user 42: file does not exist"},
+	}
+	err := newErr()
+	for _, tc := range testCases {
+		if str := l.Localize(err, tc.tag); str != tc.msg {
+			t.Errorf("Localize(\"%v\", %v) is %q, want %q", err, tc.tag, str, tc.msg)
+		}
+	}
+	if str := err.Error(); str != "cannot save user 42: file does not exist" {
+		t.Errorf("Error() is %q, want %q", str, "cannot save user 42: file does not exist")
+	}
+}
+
+func TestTextCatalog(t *testing.T) {
+	b := catalog.NewBuilder()
+	_ = b.SetString(language.Japanese, "user.save", "ユーザー %d を保存できません")
+	_ = b.SetString(language.Japanese, "file.notfound", "ファイル %s が見つかりません")
+	l := NewLocalizer(NewTextCatalog(b))
+	testCases := []struct {
+		tag language.Tag
+		msg string
+	}{
+		{tag: language.Japanese, msg: "ユーザー 42 を保存できません: ファイル users.json が見つかりません"},
+		{tag: language.English, msg: "cannot save user 42: file does not exist"},
+	}
+	err := newErr()
+	for _, tc := range testCases {
+		if str := l.Localize(err, tc.tag); str != tc.msg {
+			t.Errorf("Localize(\"%v\", %v) is %q, want %q", err, tc.tag, str, tc.msg)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	_ = message.SetString(language.Japanese, "user.save", "ユーザー %d を保存できません")
+	err := newErr()
+	if str := Localize(err, language.Japanese); str != "ユーザー 42 を保存できません: file does not exist" {
+		t.Errorf("Localize(\"%v\") is %q, want %q", err, str, "ユーザー 42 を保存できません: file does not exist")
+	}
+	if str := Localize(nil, language.Japanese); str != "<nil>" {
+		t.Errorf("Localize(nil) is %q, want %q", str, "<nil>")
+	}
+
+	SetCatalog(MapCatalog{language.Japanese: {"file.notfound": "ファイル %s が見つかりません"}})
+	t.Cleanup(func() { SetCatalog(NewTextCatalog(message.DefaultCatalog)) })
+	if str := Localize(err, language.Japanese); str != "cannot save user 42: ファイル users.json が見つかりません" {
+		t.Errorf("Localize(\"%v\") is %q, want %q", err, str, "cannot save user 42: ファイル users.json が見つかりません")
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,146 @@
+// Package errsotel records error instances to OpenTelemetry spans.
+package errsotel
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	//DefaultPrefix is default prefix of span attributes for context of error instances.
+	DefaultPrefix = "error.context."
+	//exceptionEventName is the name of exception event.
+	exceptionEventName = "exception"
+)
+
+//config type is configuration of Record function.
+type config struct {
+	prefix string
+}
+
+//Option type is self-referential function type for Record function. (functional options pattern)
+type Option func(*config)
+
+//WithPrefix function returns Option function value.
+//This function is used in Record function that represents prefix of span attributes for context of error instances.
+func WithPrefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+//Record function records error instance to span.
+//Record function sets error status of span, records exception event
+//(exception.type, exception.message and exception.stacktrace attributes),
+//and sets context of every *errs.Error instance in err's tree to span attributes with prefix.
+//Context values of outer error instance take precedence (see errs.Fields function),
+//and are redacted by errs.Redacted type and global policy of redaction.
+func Record(span trace.Span, err error, opts ...Option) {
+	if span == nil || err == nil || !span.IsRecording() {
+		return
+	}
+	cfg := &config{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	span.SetStatus(codes.Error, err.Error())
+	eventAttrs := []attribute.KeyValue{
+		semconv.ExceptionType(fmt.Sprintf("%T", err)),
+		semconv.ExceptionMessage(err.Error()),
+	}
+	chain := errsChain(err)
+	if st := stackTrace(chain); len(st) > 0 {
+		eventAttrs = append(eventAttrs, semconv.ExceptionStacktrace(st))
+	}
+	span.AddEvent(exceptionEventName, trace.WithAttributes(eventAttrs...))
+
+	errType := fmt.Sprintf("%T", errs.Cause(err))
+	if code := errs.CodeOf(err); len(code) > 0 {
+		errType = string(code)
+	}
+	attrs := []attribute.KeyValue{semconv.ErrorTypeKey.String(errType)}
+	fields := errs.Fields(err)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attrs = append(attrs, attributeValue(cfg.prefix+k, errs.RedactValue(k, fields[k])))
+	}
+	span.SetAttributes(attrs...)
+}
+
+//attributeValue returns span attribute of context value.
+func attributeValue(key string, v interface{}) attribute.KeyValue {
+	switch x := v.(type) {
+	case string:
+		return attribute.String(key, x)
+	case bool:
+		return attribute.Bool(key, x)
+	case int:
+		return attribute.Int(key, x)
+	case int64:
+		return attribute.Int64(key, x)
+	case float64:
+		return attribute.Float64(key, x)
+	case []string:
+		return attribute.StringSlice(key, x)
+	case fmt.Stringer:
+		return attribute.String(key, x.String())
+	}
+	return attribute.String(key, fmt.Sprint(v))
+}
+
+//stackTrace returns stack trace of the innermost *errs.Error instance with stack trace.
+//The format is similar to goroutine stack traces of Go runtime.
+func stackTrace(chain []*errs.Error) string {
+	for i := len(chain) - 1; i >= 0; i-- {
+		frames := chain[i].StackTrace()
+		if len(frames) == 0 {
+			continue
+		}
+		b := &strings.Builder{}
+		for _, f := range frames {
+			fmt.Fprintf(b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		}
+		return b.String()
+	}
+	return ""
+}
+
+//errsChain returns *errs.Error instances in err's tree by pre-order traversal.
+//Cyclic branches and branches deeper than errs.MaxDepth are skipped. (see errs.Walk function)
+func errsChain(err error) []*errs.Error {
+	list := []*errs.Error{}
+	errs.Walk(err, func(n errs.Node) bool {
+		if e, ok := n.Err.(*errs.Error); ok && e != nil && !n.Cycle && !n.Truncated {
+			list = append(list, e)
+		}
+		return true
+	})
+	return list
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,143 @@
+package errsotel
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/errs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var codeTest = errs.RegisterCode("OTEL-001", "error for test", errs.SeverityError, "")
+
+func recordSpan(t *testing.T, err error, opts ...Option) tracetest.SpanStub {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+
+	_, span := tp.Tracer("errsotel").Start(context.Background(), "test")
+	Record(span, err, opts...)
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("count of spans is %d, want 1", len(spans))
+	}
+	return spans[0]
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := map[attribute.Key]attribute.Value{}
+	for _, a := range attrs {
+		m[a.Key] = a.Value
+	}
+	return m
+}
+
+func TestRecord(t *testing.T) {
+	err := errs.Wrap(
+		errs.New("inner error", errs.WithCause(os.ErrNotExist), errs.WithContext("path", "not-exist.txt"), errs.WithContext("id", 1)),
+		errs.WithCode(codeTest),
+		errs.WithContext("id", 2),
+		errs.WithContext("ok", true),
+	)
+	span := recordSpan(t, err)
+
+	if span.Status.Code != codes.Error || span.Status.Description != err.Error() {
+		t.Errorf("status of span is %v, want %v", span.Status, err.Error())
+	}
+	if len(span.Events) != 1 || span.Events[0].Name != "exception" {
+		t.Fatalf("events of span is %v, want exception event", span.Events)
+	}
+	event := attributeMap(span.Events[0].Attributes)
+	if v := event["exception.type"].AsString(); v != "*errs.Error" {
+		t.Errorf("exception.type is %v, want %v", v, "*errs.Error")
+	}
+	if v := event["exception.message"].AsString(); v != "inner error: file does not exist" {
+		t.Errorf("exception.message is %v, want %v", v, "inner error: file does not exist")
+	}
+	if v := event["exception.stacktrace"].AsString(); !strings.HasPrefix(v, "github.com/spiegel-im-spiegel/errs/errsotel.TestRecord\n\t") {
+		t.Errorf("exception.stacktrace is %v, want stack trace of TestRecord", v)
+	}
+
+	attrs := attributeMap(span.Attributes)
+	testCases := []struct {
+		key   attribute.Key
+		value attribute.Value
+	}{
+		{key: "error.type", value: attribute.StringValue("OTEL-001")},
+		{key: "error.context.id", value: attribute.IntValue(2)},
+		{key: "error.context.ok", value: attribute.BoolValue(true)},
+		{key: "error.context.path", value: attribute.StringValue("not-exist.txt")},
+		{key: "error.context.function", value: attribute.StringValue("github.com/spiegel-im-spiegel/errs/errsotel.TestRecord")},
+	}
+	for _, tc := range testCases {
+		if v, ok := attrs[tc.key]; !ok || v != tc.value {
+			t.Errorf("attribute %v is %v, want %v", tc.key, v.Emit(), tc.value.Emit())
+		}
+	}
+}
+
+func TestRecordWithPrefix(t *testing.T) {
+	span := recordSpan(t, errs.New("error", errs.WithContext("foo", "bar")), WithPrefix("app."))
+	attrs := attributeMap(span.Attributes)
+	if v := attrs["app.foo"]; v.AsString() != "bar" {
+		t.Errorf("attribute app.foo is %v, want %v", v.Emit(), "bar")
+	}
+	if v := attrs["error.type"]; v.AsString() != "*errs.Error" {
+		t.Errorf("attribute error.type is %v, want %v", v.Emit(), "*errs.Error")
+	}
+}
+
+func TestRecordNonErrs(t *testing.T) {
+	span := recordSpan(t, os.ErrInvalid)
+	if len(span.Events) != 1 {
+		t.Fatalf("events of span is %v, want exception event", span.Events)
+	}
+	event := attributeMap(span.Events[0].Attributes)
+	if _, ok := event["exception.stacktrace"]; ok {
+		t.Errorf("exception.stacktrace is %v, want nothing", event["exception.stacktrace"].Emit())
+	}
+}
+
+func TestRecordCycle(t *testing.T) {
+	inner := errs.New("inner", errs.WithContext("foo", "bar")).(*errs.Error)
+	outer := errs.Wrap(inner, errs.WithContext("id", 1))
+	inner.Cause = outer
+	span := recordSpan(t, outer)
+	attrs := attributeMap(span.Attributes)
+	if v := attrs["error.context.foo"]; v.AsString() != "bar" {
+		t.Errorf("attribute error.context.foo is %v, want %v", v.Emit(), "bar")
+	}
+	if span.Status.Code != codes.Error {
+		t.Errorf("status of span is %v, want %v", span.Status.Code, codes.Error)
+	}
+}
+
+func TestRecordNil(t *testing.T) {
+	span := recordSpan(t, nil)
+	if span.Status.Code != codes.Unset || len(span.Events) != 0 || len(span.Attributes) != 0 {
+		t.Errorf("span is %v, want no error", span)
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
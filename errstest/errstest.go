@@ -0,0 +1,319 @@
+// Package errstest provides test assertions for error chains built by errs package.
+// Assertions check the structure of error tree instead of comparing %+v (JSON) strings,
+// and report the whole error tree on failure.
+package errstest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/errs"
+)
+
+//AssertIs function asserts that err's tree has target (see errs.Is function).
+func AssertIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	if errs.Is(err, target) {
+		return true
+	}
+	t.Errorf("errs.Is(err, target) is false\ntarget: %s\nerror tree:\n%s", node(target), Tree(err))
+	return false
+}
+
+//AssertNotIs function asserts that err's tree does not have target (see errs.Is function).
+func AssertNotIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	if !errs.Is(err, target) {
+		return true
+	}
+	t.Errorf("errs.Is(err, target) is true\ntarget: %s\nerror tree:\n%s", node(target), Tree(err))
+	return false
+}
+
+//AssertAs function asserts that err's tree has error of type T, and returns the first one (see errs.As function).
+func AssertAs[T any](t testing.TB, err error) (T, bool) {
+	t.Helper()
+	var target T
+	if errs.As(err, &target) {
+		return target, true
+	}
+	t.Errorf("errs.As(err, *%v) is false\nerror tree:\n%s", reflect.TypeOf((*T)(nil)).Elem(), Tree(err))
+	return target, false
+}
+
+//AssertContext function asserts that merged context of err's tree (see errs.Fields function) has key with value.
+//Values are compared by reflect.DeepEqual function, and the original value of errs.Redacted type is compared.
+func AssertContext(t testing.TB, err error, key string, value interface{}) bool {
+	t.Helper()
+	v, ok := errs.Fields(err)[key]
+	if !ok {
+		t.Errorf("context %q is not found\nerror tree:\n%s", key, Tree(err))
+		return false
+	}
+	if !equalValue(v, value) {
+		t.Errorf("context %q is %#v, want %#v\nerror tree:\n%s", key, v, value, Tree(err))
+		return false
+	}
+	return true
+}
+
+//AssertCode function asserts that the first error code in err's tree is code (see errs.CodeOf function).
+func AssertCode(t testing.TB, err error, code errs.Code) bool {
+	t.Helper()
+	if c := errs.CodeOf(err); c != code {
+		t.Errorf("error code is %q, want %q\nerror tree:\n%s", c, code, Tree(err))
+		return false
+	}
+	return true
+}
+
+//AssertChain function asserts that errors in err's tree (pre-order traversal) match matchers in order.
+//An error may match successive matchers, and errors that match no matcher may be between matched errors.
+func AssertChain(t testing.TB, err error, matchers ...Matcher) bool {
+	t.Helper()
+	i := 0
+	errs.Walk(err, func(n errs.Node) bool {
+		if n.Cycle || n.Truncated {
+			return true
+		}
+		for i < len(matchers) && matchers[i].match(n.Err) {
+			i++
+		}
+		return i < len(matchers)
+	})
+	if i == len(matchers) {
+		return true
+	}
+	b := &strings.Builder{}
+	for j, m := range matchers {
+		mark := "ok"
+		switch {
+		case j == i:
+			mark = "NG"
+		case j > i:
+			mark = "--"
+		}
+		fmt.Fprintf(b, "  %s %s\n", mark, m.desc)
+	}
+	t.Errorf("error chain does not match\nmatchers:\n%serror tree:\n%s", b.String(), Tree(err))
+	return false
+}
+
+//Matcher type is matcher of an error in error tree for AssertChain function.
+type Matcher struct {
+	desc  string
+	match func(error) bool
+}
+
+//String method returns description of Matcher.
+//This method is a implementation of fmt.Stringer interface.
+func (m Matcher) String() string {
+	return m.desc
+}
+
+//MatchFunc function returns Matcher by function.
+func MatchFunc(desc string, fn func(error) bool) Matcher {
+	return Matcher{desc: desc, match: fn}
+}
+
+//Is function returns Matcher that matches target itself, or error with Is method returning true (other than errs.Error type).
+func Is(target error) Matcher {
+	comparable := target != nil && reflect.TypeOf(target).Comparable()
+	return MatchFunc("Is("+node(target)+")", func(err error) bool {
+		if comparable && err == target {
+			return true
+		}
+		if _, ok := err.(*errs.Error); ok {
+			return false
+		}
+		x, ok := err.(interface{ Is(error) bool })
+		return ok && x.Is(target)
+	})
+}
+
+//Type function returns Matcher that matches error of type T.
+func Type[T any]() Matcher {
+	return MatchFunc(fmt.Sprintf("Type(%v)", reflect.TypeOf((*T)(nil)).Elem()), func(err error) bool {
+		_, ok := interface{}(err).(T)
+		return ok
+	})
+}
+
+//Msg function returns Matcher that matches error with message (Error method).
+func Msg(msg string) Matcher {
+	return MatchFunc(fmt.Sprintf("Msg(%q)", msg), func(err error) bool {
+		return err.Error() == msg
+	})
+}
+
+//Code function returns Matcher that matches errs.Error instance with error code.
+func Code(code errs.Code) Matcher {
+	return MatchFunc(fmt.Sprintf("Code(%q)", code), func(err error) bool {
+		e, ok := err.(*errs.Error)
+		return ok && e != nil && e.Code == code
+	})
+}
+
+//Kind function returns Matcher that matches errs.Error instance with the kind or its descendant kind.
+func Kind(kind *errs.Kind) Matcher {
+	return MatchFunc(fmt.Sprintf("Kind(%v)", kind), func(err error) bool {
+		e, ok := err.(*errs.Error)
+		return ok && e != nil && e.Kind != nil && e.Kind.Is(kind)
+	})
+}
+
+//Context function returns Matcher that matches errs.Error instance with context (key and value).
+func Context(key string, value interface{}) Matcher {
+	return MatchFunc(fmt.Sprintf("Context(%q, %#v)", key, value), func(err error) bool {
+		e, ok := err.(*errs.Error)
+		if !ok || e == nil {
+			return false
+		}
+		v, ok := e.Context[key]
+		return ok && equalValue(v, value)
+	})
+}
+
+//Tree function returns readable text of err's tree for failure messages.
+//Each line is an error in the tree (see errs.Walk function) with type, message, error code, kind and context (sorted by key).
+//Cyclic and truncated branches are marked with "<cycle>" and "<truncated>".
+func Tree(err error) string {
+	if err == nil {
+		return "  <nil>\n"
+	}
+	b := &strings.Builder{}
+	errs.Walk(err, func(n errs.Node) bool {
+		b.WriteString(strings.Repeat("  ", n.Depth+1))
+		if len(n.Label) > 0 {
+			b.WriteString(n.Label + ": ")
+		}
+		switch {
+		case n.Cycle:
+			b.WriteString("<cycle> ")
+		case n.Truncated:
+			b.WriteString("<truncated> ")
+		}
+		b.WriteString(node(n.Err))
+		b.WriteByte('\n')
+		return true
+	})
+	return b.String()
+}
+
+//AssertTree function asserts that got and want have the same error tree (see Tree function).
+//It reports the line diff of both trees on failure.
+func AssertTree(t testing.TB, got, want error) bool {
+	t.Helper()
+	if d := Diff(got, want); len(d) > 0 {
+		t.Errorf("error trees differ (-got +want):\n%s", d)
+		return false
+	}
+	return true
+}
+
+//Diff function returns the line diff of got and want error trees (see Tree function).
+//Lines only in got are prefixed with "-", lines only in want with "+", and common lines with " ".
+//It returns empty string if both trees are the same.
+func Diff(got, want error) string {
+	a := strings.SplitAfter(Tree(got), "\n")
+	b := strings.SplitAfter(Tree(want), "\n")
+	a, b = a[:len(a)-1], b[:len(b)-1]
+	// longest common subsequence of lines
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	if lcs[0][0] == len(a) && len(a) == len(b) {
+		return ""
+	}
+	d := &strings.Builder{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			d.WriteString(" " + a[i])
+			i, j = i+1, j+1
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			d.WriteString("-" + a[i])
+			i++
+		default:
+			d.WriteString("+" + b[j])
+			j++
+		}
+	}
+	return d.String()
+}
+
+//node returns readable text of an error (not including its tree). (internal)
+func node(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	e, ok := err.(*errs.Error)
+	if !ok || e == nil {
+		return fmt.Sprintf("%T %q", err, err.Error())
+	}
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%T %q", e, e.Error())
+	if len(e.Code) > 0 {
+		fmt.Fprintf(b, " code=%s", e.Code)
+	}
+	if e.Kind != nil {
+		fmt.Fprintf(b, " kind=%s", e.Kind)
+	}
+	if len(e.Context) > 0 {
+		keys := make([]string, 0, len(e.Context))
+		for k := range e.Context {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteString(" context={")
+		for i, k := range keys {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(b, "%s: %#v", k, errs.RedactValue(k, e.Context[k]))
+		}
+		b.WriteString("}")
+	}
+	return b.String()
+}
+
+//equalValue reports whether context value v equals want. (internal)
+func equalValue(v, want interface{}) bool {
+	if r, ok := v.(errs.Redacted); ok {
+		if _, ok := want.(errs.Redacted); !ok {
+			v = r.Value()
+		}
+	}
+	return reflect.DeepEqual(v, want)
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,211 @@
+package errstest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spiegel-im-spiegel/errs"
+)
+
+//fakeTB records failures instead of failing test.
+type fakeTB struct {
+	testing.TB
+	msgs []string
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Errorf(format string, args ...interface{}) {
+	t.msgs = append(t.msgs, fmt.Sprintf(format, args...))
+}
+
+const codeTest = errs.Code("test")
+
+func newTestError() error {
+	return errs.Wrap(
+		errs.New("query failed", errs.WithCause(os.ErrInvalid), errs.WithCode(codeTest), errs.WithContext("id", 1), errs.WithoutCaller()),
+		errs.WithKind(errs.NotFound),
+		errs.WithContext("path", "users"),
+		errs.WithoutCaller(),
+	)
+}
+
+func TestAssertions(t *testing.T) {
+	err := newTestError()
+	testCases := []struct {
+		name   string
+		assert func(t testing.TB) bool
+		ok     bool
+	}{
+		{name: "AssertIs", assert: func(t testing.TB) bool { return AssertIs(t, err, os.ErrInvalid) }, ok: true},
+		{name: "AssertIs", assert: func(t testing.TB) bool { return AssertIs(t, err, os.ErrExist) }, ok: false},
+		{name: "AssertNotIs", assert: func(t testing.TB) bool { return AssertNotIs(t, err, os.ErrExist) }, ok: true},
+		{name: "AssertNotIs", assert: func(t testing.TB) bool { return AssertNotIs(t, err, os.ErrInvalid) }, ok: false},
+		{name: "AssertContext", assert: func(t testing.TB) bool { return AssertContext(t, err, "id", 1) }, ok: true},
+		{name: "AssertContext", assert: func(t testing.TB) bool { return AssertContext(t, err, "path", "users") }, ok: true},
+		{name: "AssertContext", assert: func(t testing.TB) bool { return AssertContext(t, err, "id", 2) }, ok: false},
+		{name: "AssertContext", assert: func(t testing.TB) bool { return AssertContext(t, err, "name", "foo") }, ok: false},
+		{name: "AssertCode", assert: func(t testing.TB) bool { return AssertCode(t, err, codeTest) }, ok: true},
+		{name: "AssertCode", assert: func(t testing.TB) bool { return AssertCode(t, err, errs.Code("other")) }, ok: false},
+		{name: "AssertAs", assert: func(t testing.TB) bool { _, ok := AssertAs[*errs.Error](t, err); return ok }, ok: true},
+		{name: "AssertAs", assert: func(t testing.TB) bool { _, ok := AssertAs[*fs.PathError](t, err); return ok }, ok: false},
+	}
+	for _, tc := range testCases {
+		ft := &fakeTB{TB: t}
+		if ok := tc.assert(ft); ok != tc.ok {
+			t.Errorf("%s() is %v, want %v", tc.name, ok, tc.ok)
+		}
+		if reported := len(ft.msgs) > 0; reported == tc.ok {
+			t.Errorf("%s() reports failure %v, want %v", tc.name, reported, !tc.ok)
+		}
+		for _, msg := range ft.msgs {
+			if !strings.Contains(msg, "error tree:\n") {
+				t.Errorf("%s() reports %q, want error tree", tc.name, msg)
+			}
+		}
+	}
+}
+
+func TestAssertAsValue(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &fs.PathError{Op: "open", Path: "users.txt", Err: fs.ErrNotExist})
+	e, ok := AssertAs[*fs.PathError](t, err)
+	if !ok || e.Path != "users.txt" {
+		t.Errorf("AssertAs() is %v, want %v", e, "users.txt")
+	}
+}
+
+func TestAssertChain(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", newTestError())
+	testCases := []struct {
+		matchers []Matcher
+		ok       bool
+		mark     string
+	}{
+		{matchers: nil, ok: true},
+		{matchers: []Matcher{Type[*errs.Error](), Kind(errs.NotFound), Code(codeTest), Is(os.ErrInvalid)}, ok: true},
+		{matchers: []Matcher{Msg("wrapped: query failed: invalid argument"), Context("path", "users"), Context("id", 1), Msg("invalid argument")}, ok: true},
+		{matchers: []Matcher{MatchFunc("any", func(error) bool { return true }), Is(fs.ErrInvalid)}, ok: true},
+		{matchers: []Matcher{Is(os.ErrInvalid), Code(codeTest)}, ok: false, mark: "NG Code(\"test\")"},
+		{matchers: []Matcher{Kind(errs.Timeout), Is(os.ErrInvalid)}, ok: false, mark: "NG Kind(Timeout)"},
+		{matchers: []Matcher{Is(os.ErrExist)}, ok: false, mark: "NG Is(*errors.errorString \"file already exists\")"},
+	}
+	for _, tc := range testCases {
+		ft := &fakeTB{TB: t}
+		if ok := AssertChain(ft, err, tc.matchers...); ok != tc.ok {
+			t.Errorf("AssertChain(%v) is %v, want %v", tc.matchers, ok, tc.ok)
+		}
+		if !tc.ok {
+			if len(ft.msgs) != 1 || !strings.Contains(ft.msgs[0], tc.mark) {
+				t.Errorf("AssertChain(%v) reports %q, want %q", tc.matchers, ft.msgs, tc.mark)
+			}
+		}
+	}
+}
+
+type cycleError struct {
+	next error
+}
+
+func (e *cycleError) Error() string { return "cycle" }
+func (e *cycleError) Unwrap() error { return e.next }
+
+//multiError is error type that is not comparable.
+type multiError []error
+
+func (m multiError) Error() string   { return "multi error" }
+func (m multiError) Unwrap() []error { return m }
+
+func TestTree(t *testing.T) {
+	cyc := &cycleError{}
+	cyc.next = cyc
+	testCases := []struct {
+		err  error
+		tree string
+	}{
+		{err: nil, tree: "  <nil>\n"},
+		{err: os.ErrInvalid, tree: "  *errors.errorString \"invalid argument\"\n"},
+		{
+			err: newTestError(),
+			tree: "  *errs.Error \"query failed: invalid argument\" kind=NotFound context={path: \"users\"}\n" +
+				"    err: *errs.Error \"query failed: invalid argument\" code=test context={id: 1}\n" +
+				"      cause: *errors.errorString \"invalid argument\"\n",
+		},
+		{
+			err: errors.Join(os.ErrInvalid, fs.ErrNotExist),
+			tree: "  *errors.joinError \"invalid argument\\nfile does not exist\"\n" +
+				"    causes[0]: *errors.errorString \"invalid argument\"\n" +
+				"    causes[1]: *errors.errorString \"file does not exist\"\n",
+		},
+		{
+			err: cyc,
+			tree: "  *errstest.cycleError \"cycle\"\n" +
+				"    cause: <cycle> *errstest.cycleError \"cycle\"\n",
+		},
+		{
+			err: errs.Wrap(os.ErrInvalid, errs.WithCause(multiError{os.ErrNotExist}), errs.WithoutCaller()),
+			tree: "  *errs.Error \"invalid argument: multi error\"\n" +
+				"    err: *errors.errorString \"invalid argument\"\n" +
+				"    cause: errstest.multiError \"multi error\"\n" +
+				"      causes[0]: *errors.errorString \"file does not exist\"\n",
+		},
+	}
+	for _, tc := range testCases {
+		if str := Tree(tc.err); str != tc.tree {
+			t.Errorf("Tree(\"%v\") is\n%s, want\n%s", tc.err, str, tc.tree)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		got, want error
+		diff      string
+	}{
+		{got: nil, want: nil, diff: ""},
+		{got: newTestError(), want: newTestError(), diff: ""},
+		{
+			got:  errors.Join(os.ErrInvalid, fs.ErrNotExist),
+			want: errors.Join(os.ErrInvalid, fs.ErrExist),
+			diff: "-  *errors.joinError \"invalid argument\\nfile does not exist\"\n" +
+				"+  *errors.joinError \"invalid argument\\nfile already exists\"\n" +
+				"     causes[0]: *errors.errorString \"invalid argument\"\n" +
+				"-    causes[1]: *errors.errorString \"file does not exist\"\n" +
+				"+    causes[1]: *errors.errorString \"file already exists\"\n",
+		},
+	}
+	for _, tc := range testCases {
+		if d := Diff(tc.got, tc.want); d != tc.diff {
+			t.Errorf("Diff(\"%v\", \"%v\") is\n%s, want\n%s", tc.got, tc.want, d, tc.diff)
+		}
+	}
+}
+
+func TestAssertTree(t *testing.T) {
+	ft := &fakeTB{}
+	if !AssertTree(ft, newTestError(), newTestError()) || len(ft.msgs) > 0 {
+		t.Error("AssertTree() with same trees fails")
+	}
+	ft = &fakeTB{}
+	if AssertTree(ft, os.ErrInvalid, os.ErrExist) || len(ft.msgs) == 0 {
+		t.Error("AssertTree() with different trees succeeds")
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
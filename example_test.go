@@ -13,9 +13,9 @@ func ExampleNew() {
 		errs.WithCause(os.ErrInvalid),
 		errs.WithContext("foo", "bar"),
 	)
-	fmt.Printf("%+v", err)
+	fmt.Printf("%#v", err)
 	// Output:
-	// {"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"wrapper error"},"Context":{"foo":"bar","function":"github.com/spiegel-im-spiegel/errs_test.ExampleNew"},"Cause":{"Type":"*errors.errorString","Msg":"invalid argument"}}
+	// *errs.Error{Err:&errors.errorString{s:"wrapper error"}, Cause:&errors.errorString{s:"invalid argument"}, Context:map[string]interface {}{"foo":"bar", "function":"github.com/spiegel-im-spiegel/errs_test.ExampleNew"}}
 }
 
 func ExampleError() {
@@ -24,9 +24,30 @@ func ExampleError() {
 		errs.WithContext("foo1", "bar1"),
 	)
 	_ = err.(*errs.Error).SetContext("foo2", "bar2")
+	fmt.Printf("%#v", err)
+	// Output:
+	// *errs.Error{Err:&errors.errorString{s:"invalid argument"}, Cause:<nil>, Context:map[string]interface {}{"foo1":"bar1", "foo2":"bar2", "function":"github.com/spiegel-im-spiegel/errs_test.ExampleError"}}
+}
+
+func ExampleError_StackTrace() {
+	err := errs.New("error with stack trace")
+	if e, ok := err.(*errs.Error); ok {
+		fmt.Println(e.StackTrace()[0].Function)
+	}
+	// Output:
+	// github.com/spiegel-im-spiegel/errs_test.ExampleError_StackTrace
+}
+
+func ExampleError_format() {
+	err := errs.New(
+		"wrapper error",
+		errs.WithCause(os.ErrInvalid),
+		errs.WithContext("foo", "bar"),
+		errs.WithoutCaller(), //"function" context and "StackTrace" element are omitted
+	)
 	fmt.Printf("%+v", err)
 	// Output:
-	// {"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"invalid argument"},"Context":{"foo1":"bar1","foo2":"bar2","function":"github.com/spiegel-im-spiegel/errs_test.ExampleError"}}
+	// {"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"wrapper error"},"Context":{"foo":"bar"},"Cause":{"Type":"*errors.errorString","Msg":"invalid argument"}}
 }
 
 func ExampleCause() {
@@ -43,6 +64,59 @@ func ExampleEncodeJSON() {
 	// {"Type":"*fs.PathError","Msg":"open not-exist.txt: no such file or directory","Cause":{"Type":"syscall.Errno","Msg":"no such file or directory"}}
 }
 
+func ExampleDecodeJSON() {
+	err, e := errs.DecodeJSON([]byte(`{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"file open error"},"Context":{"path":"not-exist.txt"},"Cause":{"Type":"*fs.PathError","Msg":"open not-exist.txt: no such file or directory","Cause":{"Type":"syscall.Errno","Msg":"no such file or directory"}}}`))
+	if e != nil {
+		fmt.Println(e)
+		return
+	}
+	fmt.Println(err)
+	fmt.Println(errs.Cause(err))
+	// Output:
+	// file open error: open not-exist.txt: no such file or directory
+	// no such file or directory
+}
+
+var codeNotFound = errs.RegisterCode("APP-404", "resource not found", errs.SeverityError, "https://example.com/errors/APP-404")
+
+func ExampleNewCode() {
+	err := errs.Wrap(
+		errs.NewCode(codeNotFound, errs.WithContext("id", 123)),
+		errs.WithContext("foo", "bar"),
+	)
+	code := errs.CodeOf(err)
+	info := code.Info()
+	fmt.Println(err)
+	fmt.Println(code)
+	fmt.Println(info.Severity)
+	fmt.Println(info.URL)
+	// Output:
+	// resource not found
+	// APP-404
+	// error
+	// https://example.com/errors/APP-404
+}
+
+var keyPath = errs.NewKey[string]("path")
+
+func ExampleNewKey() {
+	fmt.Println(keyPath.Name())
+	// Output:
+	// github.com/spiegel-im-spiegel/errs_test.path
+}
+
+func ExampleValue() {
+	err := errs.Wrap(
+		errs.New("file open error", errs.WithValue(keyPath, "not-exist.txt")),
+		errs.WithContext("foo", "bar"),
+	)
+	if path, ok := errs.Value(err, keyPath); ok {
+		fmt.Println(path)
+	}
+	// Output:
+	// not-exist.txt
+}
+
 /* Copyright 2019,2020 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
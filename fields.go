@@ -0,0 +1,49 @@
+package errs
+
+//Fields function returns context information of every Error instance in err's tree merged into one map.
+//Error instances are visited by pre-order traversal (outer instance first, then Err and Cause branches in this order),
+//and the first value of each key wins. So context of the outermost Error instance takes precedence on a collision.
+//Fields function returns nil if err's tree has no context information.
+//Values are not redacted. (Use RedactValue function to output them.)
+func Fields(err error) map[string]interface{} {
+	var fields map[string]interface{}
+	walk(err, func(err error) bool {
+		e, ok := err.(*Error)
+		if !ok || e == nil {
+			return true
+		}
+		for k, v := range e.Context {
+			if fields == nil {
+				fields = map[string]interface{}{}
+			}
+			if _, ok := fields[k]; !ok {
+				fields[k] = v
+			}
+		}
+		return true
+	})
+	return fields
+}
+
+//WithMergedFields function returns EncodeOption function value.
+//This option adds context information merged by Fields function to the top level of JSON data as the "Fields" element.
+func WithMergedFields() EncodeOption {
+	return func(o *encodeOptions) {
+		o.fields = true
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,63 @@
+package errs
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	inner := New("inner", WithCause(os.ErrInvalid), WithContext("path", "inner.txt"), WithContext("id", 1))
+	outer := Wrap(inner, WithContext("path", "outer.txt"), WithContext("user", "alice"))
+	testCases := []struct {
+		err    error
+		fields map[string]interface{}
+	}{
+		{err: nil, fields: nil},
+		{err: os.ErrInvalid, fields: nil},
+		{err: inner, fields: map[string]interface{}{"function": "github.com/spiegel-im-spiegel/errs.TestFields", "path": "inner.txt", "id": 1}},
+		{err: outer, fields: map[string]interface{}{"function": "github.com/spiegel-im-spiegel/errs.TestFields", "path": "outer.txt", "id": 1, "user": "alice"}},
+		{err: Join(os.ErrInvalid, New("joined", WithContext("id", 2)), outer), fields: map[string]interface{}{"function": "github.com/spiegel-im-spiegel/errs.TestFields", "path": "outer.txt", "id": 2, "user": "alice"}},
+	}
+	for _, tc := range testCases {
+		if fields := Fields(tc.err); !reflect.DeepEqual(fields, tc.fields) {
+			t.Errorf("Fields(\"%v\") is %v, want %v", tc.err, fields, tc.fields)
+		}
+	}
+}
+
+func TestEncodeJSONWithMergedFields(t *testing.T) {
+	inner := New("inner", WithContext("path", "inner.txt"), WithSecret("token", "secret"))
+	testCases := []struct {
+		err  error
+		json string
+	}{
+		{err: nil, json: `null`},
+		{err: os.ErrInvalid, json: `{"Type":"*errors.errorString","Msg":"invalid argument"}`},
+		{err: Wrap(inner, WithContext("path", "outer.txt")), json: `{"Type":"*errs.Error","Err":{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"inner"},"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestEncodeJSONWithMergedFields","path":"inner.txt","token":"[REDACTED]"}},"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestEncodeJSONWithMergedFields","path":"outer.txt"},"Fields":{"function":"github.com/spiegel-im-spiegel/errs.TestEncodeJSONWithMergedFields","path":"outer.txt","token":"[REDACTED]"}}`},
+	}
+	for _, tc := range testCases {
+		if str := trimStackTrace(EncodeJSON(tc.err, WithMergedFields())); str != tc.json {
+			t.Errorf("EncodeJSON(\"%v\", WithMergedFields()) is %v, want %v", tc.err, str, tc.json)
+		}
+	}
+	if str := EncodeJSON(inner); strings.Contains(str, `"Fields"`) {
+		t.Errorf("EncodeJSON(\"%v\") is %v, want no Fields element", inner, str)
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
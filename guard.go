@@ -0,0 +1,356 @@
+package errs
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	//DefaultMaxDepth is the default maximum depth of traversal in error tree.
+	DefaultMaxDepth = 64
+	//cycleString is the marker string of cyclic error chain.
+	cycleString = "<cycle>"
+	//truncatedString is the marker string of error chain truncated by maximum depth.
+	truncatedString = "<truncated>"
+	//cycleTypeName is the type name of marker in JSON data for cyclic error chain.
+	cycleTypeName = "errs.cycle"
+)
+
+var maxDepth atomic.Int32
+
+//SetMaxDepth function sets maximum depth of traversal in error tree.
+//The limit is applied to every traversal in this package (Cause, Causes, Is, As, Error, EncodeJSON, LogValue and so on).
+//SetMaxDepth(0) (or negative value) resets the limit to DefaultMaxDepth.
+func SetMaxDepth(depth int) {
+	if depth <= 0 {
+		depth = 0
+	}
+	maxDepth.Store(int32(depth))
+}
+
+//MaxDepth function returns maximum depth of traversal in error tree.
+func MaxDepth() int {
+	if depth := maxDepth.Load(); depth > 0 {
+		return int(depth)
+	}
+	return DefaultMaxDepth
+}
+
+//guardState type is result of entering node in error tree. (internal)
+type guardState int
+
+const (
+	guardOK guardState = iota
+	guardCycle
+	guardTruncated
+)
+
+//guard type detects cycles and limits depth in traversal of error tree. (internal)
+//Error instances of comparable type on the path from the root are compared to detect cycles.
+type guard struct {
+	path      []error
+	max       int
+	translate TranslateFunc
+}
+
+//newGuard returns guard instance. (internal)
+func newGuard() *guard {
+	return &guard{max: MaxDepth()}
+}
+
+//enter method pushes err to the path if err is neither cyclic nor too deep. (internal)
+func (g *guard) enter(err error) guardState {
+	if len(g.path) >= g.max {
+		return guardTruncated
+	}
+	if err != nil && reflect.TypeOf(err).Comparable() {
+		for _, p := range g.path {
+			if p == err {
+				return guardCycle
+			}
+		}
+	}
+	g.path = append(g.path, err)
+	return guardOK
+}
+
+//leave method pops the path. (internal)
+func (g *guard) leave() {
+	g.path = g.path[:len(g.path)-1]
+}
+
+//joinErrorType is the type of error instance returned by errors.Join function. (internal)
+var joinErrorType = reflect.TypeOf(errors.Join(errors.New("")))
+
+//errorMessage returns message of err with guard (not redacted). (internal)
+func errorMessage(err error) string {
+	if _, ok := err.(*Error); !ok && err != nil && reflect.TypeOf(err) != joinErrorType {
+		return err.Error()
+	}
+	return newGuard().message(err)
+}
+
+//message method returns message of err. (internal)
+func (g *guard) message(err error) string {
+	switch g.enter(err) {
+	case guardCycle:
+		return cycleString
+	case guardTruncated:
+		return truncatedString
+	}
+	defer g.leave()
+
+	if err == nil {
+		return nilAngleString
+	}
+	if e, ok := err.(*Error); ok {
+		if e == nil {
+			return nilAngleString
+		}
+		var msg string
+		if s, ok := g.translateMessage(e); ok {
+			msg = s
+		} else if t, ok := e.Err.(*messageTemplate); ok {
+			msg = t.render(e.Context, redactionPolicy.Load())
+		} else {
+			msg = g.message(e.Err)
+		}
+		if e.Cause == nil {
+			return msg
+		}
+		if len(msg) == 0 {
+			return g.message(e.Cause)
+		}
+		return msg + ": " + g.message(e.Cause)
+	}
+	if reflect.TypeOf(err) == joinErrorType {
+		//the same as errors.Join function, but with guard
+		msgs := []string{}
+		for _, u := range err.(interface{ Unwrap() []error }).Unwrap() {
+			msgs = append(msgs, g.message(u))
+		}
+		return strings.Join(msgs, "\n")
+	}
+	return err.Error()
+}
+
+//branch type is a wrapped error with the name of branch. (internal)
+type branch struct {
+	name  string //"err", "cause" or "causes"
+	index int    //index in Unwrap() []error method ("causes" branch only)
+	err   error
+}
+
+//label method returns label of branch: "err", "cause" or "causes[i]". (internal)
+func (b branch) label() string {
+	if b.name == "causes" {
+		return b.name + "[" + strconv.Itoa(b.index) + "]"
+	}
+	return b.name
+}
+
+//branchesOf returns non-nil wrapped errors of err in order. (internal)
+//Error instance has "err" branch (only if created by Wrap function) and "cause" branch.
+//Other error has "cause" branch by Unwrap() error method or "causes" branches by Unwrap() []error method.
+func branchesOf(err error) []branch {
+	switch e := err.(type) {
+	case *Error:
+		if e == nil {
+			return nil
+		}
+		bs := make([]branch, 0, 2)
+		if e.wrapFlag && e.Err != nil {
+			bs = append(bs, branch{name: "err", err: e.Err})
+		}
+		if e.Cause != nil {
+			bs = append(bs, branch{name: "cause", err: e.Cause})
+		}
+		return bs
+	case interface{ Unwrap() error }:
+		if u := e.Unwrap(); u != nil {
+			return []branch{{name: "cause", err: u}}
+		}
+	case interface{ Unwrap() []error }:
+		bs := []branch{}
+		for i, u := range e.Unwrap() {
+			if u != nil {
+				bs = append(bs, branch{name: "causes", index: i, err: u})
+			}
+		}
+		return bs
+	}
+	return nil
+}
+
+//Node type is an error in error tree visited by Walk function.
+type Node struct {
+	//Err is the error instance.
+	Err error
+	//Depth is depth from the root error (0).
+	Depth int
+	//Label is the name of branch from the parent error: "err" and "cause" for Error instance,
+	//"cause" for Unwrap() error method, "causes[i]" for Unwrap() []error method, and empty string for the root error.
+	Label string
+	//Cycle reports whether Err is already on the path from the root error. Branches of Err are not visited.
+	Cycle bool
+	//Truncated reports whether Err is deeper than MaxDepth. Err and its branches are not visited.
+	Truncated bool
+}
+
+//WalkFunc type is function called for each error in error tree by Walk function.
+//Walk function stops traversal if WalkFunc function returns false.
+type WalkFunc func(n Node) bool
+
+//Walk function calls fn for each error in err's tree by pre-order traversal.
+//Cyclic branches and branches deeper than MaxDepth are reported once as Node with Cycle or Truncated flag, and not followed.
+func Walk(err error, fn WalkFunc) {
+	newGuard().walk(err, "", fn)
+}
+
+//walk calls fn for each error in err's tree by pre-order traversal until fn returns false. (internal)
+//Cyclic branches and branches deeper than MaxDepth are skipped.
+func walk(err error, fn func(error) bool) bool {
+	return newGuard().walk(err, "", func(n Node) bool {
+		if n.Cycle || n.Truncated {
+			return true
+		}
+		return fn(n.Err)
+	})
+}
+
+//walk method calls fn for each error in err's tree by pre-order traversal. (internal)
+func (g *guard) walk(err error, label string, fn WalkFunc) bool {
+	if err == nil {
+		return true
+	}
+	switch g.enter(err) {
+	case guardCycle:
+		return fn(Node{Err: err, Depth: len(g.path), Label: label, Cycle: true})
+	case guardTruncated:
+		return fn(Node{Err: err, Depth: len(g.path), Label: label, Truncated: true})
+	}
+	defer g.leave()
+	if !fn(Node{Err: err, Depth: len(g.path) - 1, Label: label}) {
+		return false
+	}
+	for _, b := range branchesOf(err) {
+		if !g.walk(b.err, b.label(), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+//is reports whether any error in err's tree matches target. (internal)
+//The rule is the same as errors.Is function with Is method of Error instances, but with guard.
+func is(err, target error) bool {
+	if err == nil || target == nil {
+		return err == target
+	}
+	var cause error
+	if c := Cause(target); c != nil {
+		if reflect.TypeOf(c) != reflect.TypeOf(target) || (reflect.TypeOf(target).Comparable() && c != target) {
+			cause = c
+		}
+	}
+	return isTarget(err, target, cause)
+}
+
+//isTarget reports whether any error in err's tree matches target.
+//If cause is not nil, Error instances in the tree also match the cause of target.
+//If target is Kind instance, errors of the kind or its descendant kinds in the tree match target. (see KindOf function) (internal)
+//Is method of Error instances in the tree is not called; its rule is applied inline instead.
+func isTarget(err, target, cause error) bool {
+	comparable := reflect.TypeOf(target).Comparable()
+	kind, _ := target.(*Kind)
+	found := false
+	walk(err, func(n error) bool {
+		if matchTarget(n, target, comparable) {
+			found = true
+			return false
+		}
+		if kind != nil {
+			if k := kindOfNode(n); k != nil && k.in(kind) {
+				found = true
+				return false
+			}
+		}
+		if e, ok := n.(*Error); ok && e != nil {
+			if !e.wrapFlag && e.Err != nil && matchTarget(e.Err, target, comparable) {
+				found = true
+				return false
+			}
+			if cause != nil && isTarget(e, cause, nil) {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+//matchTarget reports whether n matches target by comparison or Is method. (internal)
+func matchTarget(n, target error, comparable bool) bool {
+	if comparable && n == target {
+		return true
+	}
+	if _, ok := n.(*Error); ok {
+		return false
+	}
+	if x, ok := n.(interface{ Is(error) bool }); ok && x.Is(target) {
+		return true
+	}
+	return false
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+//as finds the first error in err's tree that matches target, and if one is found, sets target to that error value. (internal)
+//The rule is the same as errors.As function, but with guard.
+func as(err error, target interface{}) bool {
+	if target == nil {
+		panic("errs: target cannot be nil")
+	}
+	val := reflect.ValueOf(target)
+	typ := val.Type()
+	if typ.Kind() != reflect.Ptr || val.IsNil() {
+		panic("errs: target must be a non-nil pointer")
+	}
+	targetType := typ.Elem()
+	if targetType.Kind() != reflect.Interface && !targetType.Implements(errorType) {
+		panic("errs: *target must be interface or implement error")
+	}
+	found := false
+	walk(err, func(n error) bool {
+		if reflect.TypeOf(n).AssignableTo(targetType) {
+			val.Elem().Set(reflect.ValueOf(n))
+			found = true
+			return false
+		}
+		if x, ok := n.(interface{ As(interface{}) bool }); ok && x.As(target) {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
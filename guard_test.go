@@ -0,0 +1,211 @@
+package errs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func cyclicErrors() []error {
+	a := New("error a", WithCode("TEST-001")).(*Error)
+	b := Wrap(a, WithContext("foo", "bar")).(*Error)
+	a.Cause = b //SetCause method rejects cyclic cause
+
+	c := New("error c").(*Error)
+	c.Cause = errors.Join(os.ErrInvalid, c)
+
+	d := New("error d").(*Error)
+	e := New("error e", WithCause(d)).(*Error)
+	d.Cause = fmt.Errorf("wrapped: %w", e)
+	return []error{a, b, c, d}
+}
+
+func TestCycle(t *testing.T) {
+	for i, err := range cyclicErrors() {
+		if str := err.Error(); i < 3 && !strings.Contains(str, cycleString) {
+			t.Errorf("Error() is %q, want containing %v", str, cycleString)
+		}
+		if str := EncodeJSON(err); !strings.Contains(str, `{"Type":"errs.cycle"}`) {
+			t.Errorf("EncodeJSON() is %v, want containing cycle marker", str)
+		}
+		if str := fmt.Sprintf("%#v", err); i < 2 && !strings.Contains(str, cycleString) {
+			t.Errorf("%%#v is %v, want containing %v", str, cycleString)
+		}
+		buf := &bytes.Buffer{}
+		slog.New(slog.NewJSONHandler(buf, nil)).Error("test", slog.Any("error", err))
+		if str := buf.String(); !strings.Contains(str, `"type":"errs.cycle"`) {
+			t.Errorf("slog output is %v, want containing cycle marker", str)
+		}
+		if c := Cause(err); c == nil {
+			t.Errorf("Cause() is <nil>, want error")
+		}
+		if cs := Causes(err); len(cs) == 0 {
+			t.Errorf("Causes() is empty, want errors")
+		}
+		if Is(err, os.ErrNotExist) {
+			t.Errorf("Is(err, os.ErrNotExist) is true, want false")
+		}
+		if !Is(err, err) {
+			t.Errorf("Is(err, err) is false, want true")
+		}
+		var target *testError
+		if As(err, &target) {
+			t.Errorf("As(err, *testError) is true, want false")
+		}
+		_ = Fields(err)
+		_ = CodeOf(err)
+	}
+	a := cyclicErrors()[0]
+	if c := Cause(a); c != a.(*Error).Cause {
+		t.Errorf("Cause() is %#v, want the last error before the cycle", c)
+	}
+	if code := CodeOf(a); code != "TEST-001" {
+		t.Errorf("CodeOf() is %v, want %v", code, "TEST-001")
+	}
+	c := cyclicErrors()[2]
+	if !Is(c, os.ErrInvalid) {
+		t.Errorf("Is(err, os.ErrInvalid) is false, want true")
+	}
+}
+
+func TestSetCauseCycle(t *testing.T) {
+	a := New("a").(*Error)
+	w := Wrap(a)
+	if c := a.SetCause(w).Cause; c != ErrCyclicCause {
+		t.Errorf("Cause is %v, want %v", c, ErrCyclicCause)
+	}
+	b := New("b", WithCauses(os.ErrInvalid, a)).(*Error)
+	if c := a.SetCause(fmt.Errorf("wrapped: %w", b)).Cause; c != ErrCyclicCause {
+		t.Errorf("Cause is %v, want %v", c, ErrCyclicCause)
+	}
+	if c := a.SetCause(os.ErrInvalid).Cause; c != os.ErrInvalid {
+		t.Errorf("Cause is %v, want %v", c, os.ErrInvalid)
+	}
+	//errors.Is and errors.As functions of the standard library do not loop forever.
+	_ = a.SetCause(w)
+	if !errors.Is(w, ErrCyclicCause) {
+		t.Errorf("errors.Is(err, ErrCyclicCause) is false, want true")
+	}
+	if errors.Is(w, os.ErrNotExist) {
+		t.Errorf("errors.Is(err, os.ErrNotExist) is true, want false")
+	}
+	var target *testError
+	if errors.As(w, &target) {
+		t.Errorf("errors.As(err, *testError) is true, want false")
+	}
+	if str := w.Error(); str != "a: cyclic cause" {
+		t.Errorf("Error() is %q, want %q", str, "a: cyclic cause")
+	}
+}
+
+//multiError is error type with Unwrap() []error method that is not comparable.
+type multiError []error
+
+func (m multiError) Error() string   { return "multi error" }
+func (m multiError) Unwrap() []error { return m }
+
+func TestWalk(t *testing.T) {
+	testCases := []struct {
+		err   error
+		nodes []string
+	}{
+		{err: nil, nodes: []string{}},
+		{err: os.ErrInvalid, nodes: []string{"0::invalid argument"}},
+		{err: Wrap(os.ErrInvalid, WithCause(multiError{os.ErrNotExist, nil, os.ErrExist})), nodes: []string{"0::invalid argument: multi error", "1:err:invalid argument", "1:cause:multi error", "2:causes[0]:file does not exist", "2:causes[2]:file already exists"}},
+		{err: fmt.Errorf("wrapped: %w", New("error", WithCause(os.ErrInvalid))), nodes: []string{"0::wrapped: error: invalid argument", "1:cause:error: invalid argument", "2:cause:invalid argument"}},
+		{err: cyclicErrors()[2], nodes: []string{"0::error c: invalid argument\n<cycle>", "1:cause:invalid argument\nerror c: invalid argument\n<cycle>", "2:causes[0]:invalid argument", "2:causes[1] (cycle):error c: invalid argument\n<cycle>"}},
+	}
+	for _, tc := range testCases {
+		nodes := []string{}
+		Walk(tc.err, func(n Node) bool {
+			label := n.Label
+			if n.Cycle {
+				label += " (cycle)"
+			}
+			nodes = append(nodes, fmt.Sprintf("%d:%s:%v", n.Depth, label, n.Err))
+			return true
+		})
+		if !reflect.DeepEqual(nodes, tc.nodes) {
+			t.Errorf("Walk(\"%v\") visits %q, want %q", tc.err, nodes, tc.nodes)
+		}
+	}
+	n := 0
+	Walk(Wrap(os.ErrInvalid), func(Node) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("Walk() visits %d errors after stop, want %d", n, 1)
+	}
+
+	SetMaxDepth(2)
+	defer SetMaxDepth(0)
+	truncated := []int{}
+	Walk(Wrap(Wrap(Wrap(os.ErrInvalid))), func(n Node) bool {
+		if n.Truncated {
+			truncated = append(truncated, n.Depth)
+		}
+		return true
+	})
+	if !reflect.DeepEqual(truncated, []int{2}) {
+		t.Errorf("Walk() reports truncated errors at %v, want %v", truncated, []int{2})
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	SetMaxDepth(3)
+	t.Cleanup(func() { SetMaxDepth(0) })
+	if d := MaxDepth(); d != 3 {
+		t.Errorf("MaxDepth() is %v, want %v", d, 3)
+	}
+
+	err := Wrap(Wrap(Wrap(Wrap(Wrap(os.ErrInvalid, WithoutCaller()), WithoutCaller()), WithoutCaller()), WithoutCaller()), WithoutCaller())
+	if str, want := EncodeJSON(err), `{"Type":"*errs.Error","Err":{"Type":"*errs.Error","Err":{"Type":"*errs.Error","Err":{"Truncated":true}}}}`; str != want {
+		t.Errorf("EncodeJSON() is %v, want %v", str, want)
+	}
+	if str := err.Error(); str != truncatedString {
+		t.Errorf("Error() is %v, want %v", str, truncatedString)
+	}
+	if Is(err, os.ErrInvalid) {
+		t.Errorf("Is(err, os.ErrInvalid) is true, want false (truncated)")
+	}
+	if c := Cause(err); c == os.ErrInvalid {
+		t.Errorf("Cause() is %v, want truncated error", c)
+	}
+	if cs := Causes(err); len(cs) != 1 || cs[0] == os.ErrInvalid {
+		t.Errorf("Causes() is %v, want truncated error", cs)
+	}
+	buf := &bytes.Buffer{}
+	slog.New(slog.NewJSONHandler(buf, nil)).Error("test", slog.Any("error", err))
+	if str := buf.String(); !strings.Contains(str, `"truncated":true`) {
+		t.Errorf("slog output is %v, want containing truncated marker", str)
+	}
+
+	SetMaxDepth(0)
+	if d := MaxDepth(); d != DefaultMaxDepth {
+		t.Errorf("MaxDepth() is %v, want %v", d, DefaultMaxDepth)
+	}
+	if !Is(err, os.ErrInvalid) {
+		t.Errorf("Is(err, os.ErrInvalid) is false, want true")
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
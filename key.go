@@ -0,0 +1,130 @@
+package errs
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+//Key type is typed key of context information.
+//Key values are declared by NewKey function,
+//and the value is stored in Context map of Error instance by the name of the key qualified by the declaring package.
+type Key[T any] struct {
+	name string
+}
+
+var keyRegistry = struct {
+	sync.Mutex
+	keys map[string]reflect.Type
+}{keys: map[string]reflect.Type{}}
+
+//NewKey function declares typed key of context information in the package calling NewKey function.
+//The name of the key is qualified by the import path of the package (e.g. "example.com/mypkg.path"),
+//so keys of the same name in different packages do not collide.
+//Keys of the same name in the same package are the same key.
+//NewKey function panics if name is empty.
+func NewKey[T any](name string) Key[T] {
+	if len(name) == 0 {
+		panic("errs: NewKey with empty name")
+	}
+	if pkg := callerPackage(2); len(pkg) > 0 {
+		name = pkg + "." + name
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	keyRegistry.Lock()
+	defer keyRegistry.Unlock()
+	if t, ok := keyRegistry.keys[name]; ok && t != typ {
+		typ = nil //declared as different types: values are not converted in decoding
+	}
+	keyRegistry.keys[name] = typ
+	return Key[T]{name: name}
+}
+
+//callerPackage returns import path of the package of the caller. (internal)
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+	f := runtime.FuncForPC(pc)
+	if f == nil {
+		return ""
+	}
+	name := f.Name()
+	i := strings.LastIndex(name, "/") + 1
+	if j := strings.Index(name[i:], "."); j >= 0 {
+		return name[:i+j]
+	}
+	return name
+}
+
+//keyType returns type of key declared by NewKey function. (internal)
+func keyType(name string) (reflect.Type, bool) {
+	keyRegistry.Lock()
+	defer keyRegistry.Unlock()
+	typ, ok := keyRegistry.keys[name]
+	return typ, ok && typ != nil
+}
+
+//Name method returns name of Key qualified by the declaring package.
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+//String method returns name of Key.
+//This method is a implementation of fmt.Stringer interface.
+func (k Key[T]) String() string {
+	return k.name
+}
+
+//WithValue function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that represents typed context data.
+func WithValue[T any](key Key[T], value T) ErrorContextFunc {
+	return func(e *Error) {
+		_ = e.SetContext(key.name, value)
+	}
+}
+
+//Value function finds the first value of key in Context of Error instances in err's tree.
+//Value function returns false if err's tree has no value of key with type T.
+//The original value of Redacted type (see WithSecret function) is also returned.
+func Value[T any](err error, key Key[T]) (T, bool) {
+	var value T
+	found := false
+	walk(err, func(err error) bool {
+		e, ok := err.(*Error)
+		if !ok || e == nil || len(key.name) == 0 {
+			return true
+		}
+		v, ok := e.Context[key.name]
+		if !ok {
+			return true
+		}
+		if value, found = v.(T); found {
+			return false
+		}
+		if r, ok := v.(Redacted); ok {
+			if value, found = r.Value().(T); found {
+				return false
+			}
+		}
+		return true
+	})
+	return value, found
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,75 @@
+package errs
+
+import (
+	"os"
+	"testing"
+)
+
+var (
+	keyPath   = NewKey[string]("test.path")
+	keyCount  = NewKey[int]("test.count")
+	keyToken  = NewKey[string]("test.token")
+	keyAbsent = NewKey[string]("test.absent")
+)
+
+func TestValue(t *testing.T) {
+	err := Wrap(
+		New("error", WithCause(os.ErrInvalid), WithValue(keyPath, "inner.txt"), WithValue(keyCount, 2), WithSecret(keyToken.Name(), "secret")),
+		WithValue(keyPath, "outer.txt"),
+		WithContext(keyCount.Name(), "not int"),
+	)
+	if v, ok := Value(err, keyPath); !ok || v != "outer.txt" {
+		t.Errorf("Value(err, %v) is %v (%v), want %v", keyPath, v, ok, "outer.txt")
+	}
+	if v, ok := Value(err, keyCount); !ok || v != 2 {
+		t.Errorf("Value(err, %v) is %v (%v), want %v", keyCount, v, ok, 2)
+	}
+	if v, ok := Value(err, keyToken); !ok || v != "secret" {
+		t.Errorf("Value(err, %v) is %v (%v), want %v", keyToken, v, ok, "secret")
+	}
+	if v, ok := Value(err, keyAbsent); ok || v != "" {
+		t.Errorf("Value(err, %v) is %v (%v), want zero value", keyAbsent, v, ok)
+	}
+	if v, ok := Value(nil, keyPath); ok || v != "" {
+		t.Errorf("Value(nil, %v) is %v (%v), want zero value", keyPath, v, ok)
+	}
+	if v, ok := Value(Join(os.ErrInvalid, err), keyPath); !ok || v != "outer.txt" {
+		t.Errorf("Value(Join(err), %v) is %v (%v), want %v", keyPath, v, ok, "outer.txt")
+	}
+}
+
+func TestNewKey(t *testing.T) {
+	if name := keyPath.Name(); name != "github.com/spiegel-im-spiegel/errs.test.path" {
+		t.Errorf("Name() is %q, want %q", name, "github.com/spiegel-im-spiegel/errs.test.path")
+	}
+	if k := NewKey[string]("test.path"); k != keyPath {
+		t.Errorf("NewKey() of the same name is %v, want %v", k, keyPath)
+	}
+	//declared as different types
+	k := NewKey[string]("test.mixed")
+	_ = NewKey[int]("test.mixed")
+	if _, ok := keyType(k.Name()); ok {
+		t.Errorf("keyType(%q) is found, want not found", k.Name())
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewKey(\"\") does not panic")
+		}
+	}()
+	_ = NewKey[int]("")
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
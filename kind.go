@@ -0,0 +1,200 @@
+package errs
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+//Kind type is kind (class) of error with optional parent kind.
+//Kind instance is also error instance, so errors.Is(err, kind) function reports
+//whether err's tree has the kind or its descendant kind.
+type Kind struct {
+	name    string
+	parent  *Kind
+	decoded bool //undeclared kind rebuilt from JSON data
+}
+
+var _ error = (*Kind)(nil) //Kind type is compatible with error interface
+
+var kindRegistry = struct {
+	sync.RWMutex
+	kinds map[string]*Kind
+}{kinds: map[string]*Kind{}}
+
+//Predefined kinds of error.
+var (
+	NotFound    = NewKind("NotFound", nil)
+	Permission  = NewKind("Permission", nil)
+	Invalid     = NewKind("Invalid", nil)
+	Conflict    = NewKind("Conflict", nil)
+	Unavailable = NewKind("Unavailable", nil)
+	Timeout     = NewKind("Timeout", nil)
+	Canceled    = NewKind("Canceled", nil)
+	Internal    = NewKind("Internal", nil)
+)
+
+//NewKind function declares kind of error with parent kind (nil is allowed).
+//NewKind function panics if name is empty or already declared. (Declare kinds once at package initialization.)
+func NewKind(name string, parent *Kind) *Kind {
+	if len(name) == 0 {
+		panic("errs: NewKind with empty name")
+	}
+	kindRegistry.Lock()
+	defer kindRegistry.Unlock()
+	if _, ok := kindRegistry.kinds[name]; ok {
+		panic(fmt.Sprintf("errs: NewKind with duplicate name %q", name))
+	}
+	k := &Kind{name: name, parent: parent}
+	kindRegistry.kinds[name] = k
+	return k
+}
+
+//LookupKind function returns kind declared by NewKind function.
+func LookupKind(name string) (*Kind, bool) {
+	kindRegistry.RLock()
+	defer kindRegistry.RUnlock()
+	k, ok := kindRegistry.kinds[name]
+	return k, ok
+}
+
+//Name method returns name of Kind.
+func (k *Kind) Name() string {
+	if k == nil {
+		return ""
+	}
+	return k.name
+}
+
+//Parent method returns parent kind.
+func (k *Kind) Parent() *Kind {
+	if k == nil {
+		return nil
+	}
+	return k.parent
+}
+
+//Error method returns name of Kind.
+//This method is a implementation of error interface.
+func (k *Kind) Error() string {
+	if k == nil {
+		return nilAngleString
+	}
+	return k.name
+}
+
+//String method returns name of Kind.
+//This method is a implementation of fmt.Stringer interface.
+func (k *Kind) String() string {
+	return k.Error()
+}
+
+//Is method reports whether the kind is target kind or its descendant.
+//This method is used in errors.Is function.
+func (k *Kind) Is(target error) bool {
+	t, ok := target.(*Kind)
+	if !ok || t == nil {
+		return false
+	}
+	return k.in(t)
+}
+
+//in method reports whether the kind is k or its descendant. (internal)
+//Undeclared kinds rebuilt from JSON data match kinds of the same name.
+func (k *Kind) in(t *Kind) bool {
+	for depth := 0; k != nil && depth < MaxDepth(); depth++ {
+		if k == t || ((k.decoded || t.decoded) && k.name == t.name) {
+			return true
+		}
+		k = k.parent
+	}
+	return false
+}
+
+//WithKind function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that represents kind of error.
+func WithKind(kind *Kind) ErrorContextFunc {
+	return func(e *Error) {
+		_ = e.SetKind(kind)
+	}
+}
+
+//SetKind method sets kind of error.
+//SetKind method panics if the instance is frozen.
+func (e *Error) SetKind(kind *Kind) *Error {
+	if e == nil {
+		return e
+	}
+	e.mustNotFrozen("SetKind")
+	e.Kind = kind
+	return e
+}
+
+//sentinelKinds is table of standard-library errors classified automatically. (internal)
+var sentinelKinds = []struct {
+	err  error
+	kind *Kind
+}{
+	{err: fs.ErrNotExist, kind: NotFound},
+	{err: fs.ErrPermission, kind: Permission},
+	{err: fs.ErrExist, kind: Conflict},
+	{err: fs.ErrInvalid, kind: Invalid},
+	{err: context.DeadlineExceeded, kind: Timeout},
+	{err: os.ErrDeadlineExceeded, kind: Timeout},
+	{err: context.Canceled, kind: Canceled},
+}
+
+//kindOfNode returns kind of the error (not including its tree). (internal)
+//Kind of Error instance is the Kind field. Standard-library errors (fs.ErrNotExist, context.DeadlineExceeded and so on)
+//and errors with Timeout method returning true are classified automatically.
+func kindOfNode(err error) *Kind {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *Error:
+		if e == nil {
+			return nil
+		}
+		return e.Kind
+	case *Kind:
+		return e
+	}
+	for _, s := range sentinelKinds {
+		if matchTarget(err, s.err, true) {
+			return s.kind
+		}
+	}
+	if t, ok := err.(interface{ Timeout() bool }); ok && t.Timeout() {
+		return Timeout
+	}
+	return nil
+}
+
+//KindOf function finds the first kind in err's tree.
+//Standard-library errors such as fs.ErrNotExist and context.DeadlineExceeded are classified automatically.
+//KindOf function returns nil if err's tree has no kind.
+func KindOf(err error) *Kind {
+	var kind *Kind
+	walk(err, func(err error) bool {
+		kind = kindOfNode(err)
+		return kind == nil
+	})
+	return kind
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,154 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+	"testing"
+)
+
+var (
+	kindUserNotFound = NewKind("test.UserNotFound", NotFound)
+	kindQuota        = NewKind("test.Quota", Permission)
+)
+
+func TestKindIs(t *testing.T) {
+	testCases := []struct {
+		err    error
+		target error
+		res    bool
+	}{
+		{err: New("error", WithKind(NotFound)), target: NotFound, res: true},
+		{err: Wrap(New("error", WithKind(NotFound)), WithContext("foo", "bar")), target: NotFound, res: true},
+		{err: New("error", WithKind(NotFound)), target: Permission, res: false},
+		{err: New("error", WithKind(kindUserNotFound)), target: NotFound, res: true},
+		{err: New("error", WithKind(kindUserNotFound)), target: kindUserNotFound, res: true},
+		{err: New("error", WithKind(NotFound)), target: kindUserNotFound, res: false},
+		{err: New("error", WithKind(kindQuota)), target: NotFound, res: false},
+		{err: New("error", WithCause(New("cause", WithKind(Conflict)))), target: Conflict, res: true},
+		{err: Wrap(fs.ErrNotExist), target: NotFound, res: true},
+		{err: New("error", WithCause(syscall.ENOENT)), target: NotFound, res: true},
+		{err: Wrap(fs.ErrPermission), target: Permission, res: true},
+		{err: Wrap(fmt.Errorf("wrapped: %w", context.DeadlineExceeded)), target: Timeout, res: true},
+		{err: Wrap(context.Canceled), target: Canceled, res: true},
+		{err: Wrap(os.ErrInvalid), target: Invalid, res: true},
+		{err: Wrap(os.ErrInvalid), target: NotFound, res: false},
+		{err: kindUserNotFound, target: NotFound, res: true},
+		{err: NotFound, target: kindUserNotFound, res: false},
+	}
+	for _, tc := range testCases {
+		if ok := errors.Is(tc.err, tc.target); ok != tc.res {
+			t.Errorf("errors.Is(\"%v\", \"%v\") is %v, want %v", tc.err, tc.target, ok, tc.res)
+		}
+		if ok := Is(tc.err, tc.target); ok != tc.res {
+			t.Errorf("Is(\"%v\", \"%v\") is %v, want %v", tc.err, tc.target, ok, tc.res)
+		}
+	}
+	//auto-classification without Error instance in the chain works only in errs.Is function
+	if !Is(fmt.Errorf("wrapped: %w", fs.ErrNotExist), NotFound) {
+		t.Errorf("Is(fs.ErrNotExist, NotFound) is false, want true")
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	testCases := []struct {
+		err  error
+		kind *Kind
+	}{
+		{err: nil, kind: nil},
+		{err: New("error"), kind: nil},
+		{err: New("error", WithKind(kindUserNotFound)), kind: kindUserNotFound},
+		{err: Wrap(New("error", WithKind(Conflict)), WithKind(Internal)), kind: Internal},
+		{err: New("error", WithCause(os.ErrNotExist)), kind: NotFound},
+		{err: fmt.Errorf("wrapped: %w", os.ErrDeadlineExceeded), kind: Timeout},
+	}
+	for _, tc := range testCases {
+		if k := KindOf(tc.err); k != tc.kind {
+			t.Errorf("KindOf(\"%v\") is %v, want %v", tc.err, k, tc.kind)
+		}
+	}
+}
+
+func TestKindJSON(t *testing.T) {
+	err := New("error", WithKind(kindUserNotFound), WithoutCaller())
+	str := EncodeJSON(err)
+	if want := `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"error"},"Kind":"test.UserNotFound"}`; str != want {
+		t.Errorf("EncodeJSON() is %v, want %v", str, want)
+	}
+	decoded, e := DecodeJSON([]byte(str))
+	if e != nil {
+		t.Fatalf("DecodeJSON() is \"%v\", want <nil>", e)
+	}
+	if k := KindOf(decoded); k != kindUserNotFound {
+		t.Errorf("KindOf(DecodeJSON()) is %v, want %v", k, kindUserNotFound)
+	}
+	decoded, _ = DecodeJSON([]byte(`{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"error"},"Kind":"test.Undeclared"}`))
+	if k := KindOf(decoded); k.Name() != "test.Undeclared" || errors.Is(decoded, NotFound) {
+		t.Errorf("KindOf(DecodeJSON()) is %v, want undeclared kind", k)
+	}
+	//undeclared kinds are not registered
+	kindRegistry.RLock()
+	n := len(kindRegistry.kinds)
+	kindRegistry.RUnlock()
+	for i := 0; i < 100; i++ {
+		_, _ = DecodeJSON([]byte(fmt.Sprintf(`{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"error"},"Kind":"test.Undeclared%d"}`, i)))
+	}
+	if _, ok := LookupKind("test.Undeclared0"); ok {
+		t.Error("LookupKind(\"test.Undeclared0\") is found, want not found")
+	}
+	kindRegistry.RLock()
+	defer kindRegistry.RUnlock()
+	if m := len(kindRegistry.kinds); m != n {
+		t.Errorf("number of kinds is %d after decoding, want %d", m, n)
+	}
+}
+
+func TestSetKind(t *testing.T) {
+	e := New("error").(*Error)
+	if c := e.SetKind(NotFound); c != e || c.Kind != NotFound {
+		t.Errorf("SetKind() is %v, want the receiver with kind", c)
+	}
+	if k, ok := LookupKind("NotFound"); !ok || k != NotFound {
+		t.Errorf("LookupKind(\"NotFound\") is %v, want %v", k, NotFound)
+	}
+	if k := kindUserNotFound.Parent(); k != NotFound {
+		t.Errorf("Parent() is %v, want %v", k, NotFound)
+	}
+}
+
+func TestNewKindPanic(t *testing.T) {
+	testCases := []struct {
+		name string
+	}{
+		{name: ""},
+		{name: "NotFound"},
+	}
+	for _, tc := range testCases {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("NewKind(%q) does not panic", tc.name)
+				}
+			}()
+			_ = NewKind(tc.name, nil)
+		}()
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
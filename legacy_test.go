@@ -0,0 +1,104 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//legacyEncodeJSON is the previous implementation of EncodeJSON function (with fmt.Sprintf and strings.Join).
+//It is kept for checking byte-identical output and for benchmarks.
+func legacyEncodeJSON(err error) string {
+	if e, ok := err.(*Error); ok {
+		return legacyEncodeError(e)
+	}
+	if e, ok := err.(json.Marshaler); ok {
+		b, ee := json.Marshal(e)
+		if ee != nil {
+			return legacyEncodeGeneric(err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+	return legacyEncodeGeneric(err)
+}
+
+func legacyEncodeError(e *Error) string {
+	if e == nil {
+		return "null"
+	}
+	elms := []string{}
+	elms = append(elms, fmt.Sprintf(`"Type":%q`, fmt.Sprintf("%T", e)))
+	msgBuf := &bytes.Buffer{}
+	json.HTMLEscape(msgBuf, []byte(fmt.Sprintf(`"Err":%s`, legacyEncodeJSON(e.Err))))
+	elms = append(elms, msgBuf.String())
+	if len(e.Code) > 0 {
+		elms = append(elms, fmt.Sprintf(`"Code":%s`, legacyEncodeString(string(e.Code))))
+	}
+	if len(e.Context) > 0 {
+		if b, err := json.Marshal(e.SafeContext()); err == nil {
+			elms = append(elms, fmt.Sprintf(`"Context":%s`, string(b)))
+		}
+	}
+	if e.Cause != nil && !reflect.ValueOf(e.Cause).IsZero() {
+		elms = append(elms, fmt.Sprintf(`"Cause":%s`, legacyEncodeJSON(e.Cause)))
+	}
+	if st := e.StackTrace(); len(st) > 0 {
+		if b, err := json.Marshal(st); err == nil {
+			elms = append(elms, fmt.Sprintf(`"StackTrace":%s`, string(b)))
+		}
+	}
+	return "{" + strings.Join(elms, ",") + "}"
+}
+
+func legacyEncodeString(s string) string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return `""`
+	}
+	return string(b)
+}
+
+func legacyEncodeGeneric(err error) string {
+	if err == nil {
+		return "null"
+	}
+	elms := []string{}
+	elms = append(elms, fmt.Sprintf(`"Type":%q`, fmt.Sprintf("%T", err)))
+	msgBuf := &bytes.Buffer{}
+	json.HTMLEscape(msgBuf, []byte(fmt.Sprintf(`"Msg":%q`, redactString(err.Error()))))
+	elms = append(elms, msgBuf.String())
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		if unwraped := e.Unwrap(); unwraped != nil {
+			elms = append(elms, fmt.Sprintf(`"Cause":%s`, legacyEncodeJSON(unwraped)))
+		}
+	case interface{ Unwrap() []error }:
+		causes := []string{}
+		for _, unwraped := range e.Unwrap() {
+			if unwraped != nil {
+				causes = append(causes, legacyEncodeJSON(unwraped))
+			}
+		}
+		if len(causes) > 0 {
+			elms = append(elms, fmt.Sprintf(`"Causes":[%s]`, strings.Join(causes, ",")))
+		}
+	}
+	return "{" + strings.Join(elms, ",") + "}"
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
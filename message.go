@@ -0,0 +1,62 @@
+package errs
+
+//WithMessageID function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that represents ID and arguments of localized message.
+//Error method still returns the message given to New function (or the message of wrapped error);
+//Translate function (and Localize function of errsi18n package) renders localized message by the ID.
+func WithMessageID(id string, args ...interface{}) ErrorContextFunc {
+	return func(e *Error) {
+		_ = e.SetMessageID(id, args...)
+	}
+}
+
+//SetMessageID method sets ID and arguments of localized message.
+//SetMessageID method panics if the instance is frozen.
+func (e *Error) SetMessageID(id string, args ...interface{}) *Error {
+	if e == nil {
+		return e
+	}
+	e.mustNotFrozen("SetMessageID")
+	e.MessageID = id
+	e.MessageArgs = args
+	return e
+}
+
+//TranslateFunc type is function that returns message of ID with arguments.
+//TranslateFunc function returns false if the message of ID is not found.
+type TranslateFunc func(id string, args []interface{}) (string, bool)
+
+//Translate function returns error message of err in the same way as Error method,
+//but messages of Error instances with message ID are replaced by fn.
+//Messages not found by fn are the same as Error method.
+func Translate(err error, fn TranslateFunc) string {
+	if err == nil {
+		return nilAngleString
+	}
+	g := newGuard()
+	g.translate = fn
+	return redactString(g.message(err))
+}
+
+//translateMessage method returns translated message of Error instance (not including its cause). (internal)
+func (g *guard) translateMessage(e *Error) (string, bool) {
+	if g.translate == nil || len(e.MessageID) == 0 {
+		return "", false
+	}
+	return g.translate(e.MessageID, e.MessageArgs)
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
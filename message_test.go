@@ -0,0 +1,83 @@
+package errs
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+//translateJa is TranslateFunc for test.
+func translateJa(id string, args []interface{}) (string, bool) {
+	formats := map[string]string{
+		"file.open": "ファイル %s を開けません",
+		"user.save": "ユーザー %d を保存できません",
+	}
+	format, ok := formats[id]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf(format, args...), true
+}
+
+func TestTranslate(t *testing.T) {
+	testCases := []struct {
+		err  error
+		msg  string
+		tmsg string
+		json string
+	}{
+		{err: nil, msg: "<nil>", tmsg: "<nil>"},
+		{err: New("cannot open file.txt", WithMessageID("file.open", "file.txt")), msg: "cannot open file.txt", tmsg: "ファイル file.txt を開けません", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"cannot open file.txt"},"MessageID":"file.open","MessageArgs":["file.txt"],"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestTranslate"}}`},
+		{err: New("cannot save user 1", WithMessageID("user.save", 1), WithCause(New("cannot open file.txt", WithMessageID("file.open", "file.txt")))), msg: "cannot save user 1: cannot open file.txt", tmsg: "ユーザー 1 を保存できません: ファイル file.txt を開けません"},
+		{err: Wrap(os.ErrNotExist, WithMessageID("file.open", "file.txt")), msg: "file does not exist", tmsg: "ファイル file.txt を開けません"},
+		{err: New("unknown error", WithMessageID("unknown"), WithCause(os.ErrInvalid)), msg: "unknown error: invalid argument", tmsg: "unknown error: invalid argument", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"unknown error"},"MessageID":"unknown","Context":{"function":"github.com/spiegel-im-spiegel/errs.TestTranslate"},"Cause":{"Type":"*errors.errorString","Msg":"invalid argument"}}`},
+		{err: New("cannot open secret", WithMessageID("file.open", Redact("secret.txt"))), msg: "cannot open secret", tmsg: "ファイル [REDACTED] を開けません", json: `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"cannot open secret"},"MessageID":"file.open","MessageArgs":["[REDACTED]"],"Context":{"function":"github.com/spiegel-im-spiegel/errs.TestTranslate"}}`},
+	}
+	for _, tc := range testCases {
+		if str := fmt.Sprintf("%v", tc.err); str != tc.msg {
+			t.Errorf("Error() is %q, want %q", str, tc.msg)
+		}
+		if str := Translate(tc.err, translateJa); str != tc.tmsg {
+			t.Errorf("Translate(\"%v\") is %q, want %q", tc.err, str, tc.tmsg)
+		}
+		if len(tc.json) > 0 {
+			if str := trimStackTrace(EncodeJSON(tc.err)); str != tc.json {
+				t.Errorf("EncodeJSON(\"%v\") is %v, want %v", tc.err, str, tc.json)
+			}
+		}
+	}
+}
+
+func TestMessageIDDecode(t *testing.T) {
+	err := New("cannot save user 1", WithMessageID("user.save", 1))
+	decoded, decErr := DecodeJSON([]byte(EncodeJSON(err)))
+	if decErr != nil {
+		t.Fatalf("DecodeJSON() is \"%v\", want <nil>", decErr)
+	}
+	if str := Translate(decoded, translateJa); str != "ユーザー 1 を保存できません" {
+		t.Errorf("Translate(DecodeJSON()) is %q, want %q", str, "ユーザー 1 を保存できません")
+	}
+}
+
+func TestSetMessageIDFrozen(t *testing.T) {
+	e := New("error").(*Error).Freeze()
+	c := e.Clone().SetMessageID("file.open", "file.txt")
+	if c == e || len(e.MessageID) > 0 || c.MessageID != "file.open" {
+		t.Errorf("SetMessageID() of copy of frozen instance is %v (%v), want copy with message ID", c.MessageID, e.MessageID)
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,91 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+//ErrPanic is error of recovered panic.
+//Error instances returned by FromPanic and Recover functions match ErrPanic in errors.Is function.
+var ErrPanic = errors.New("panic")
+
+//FromPanic function returns an error instance of recovered panic value.
+//The error instance has the panic value as "panic" context, Internal kind,
+//and stack trace of the panic site if FromPanic function is called in deferred function while panicking.
+//If the panic value is error, it is the cause of the error instance.
+//FromPanic function returns nil if v is nil.
+//
+//	defer func() {
+//		if v := recover(); v != nil {
+//			err = errs.FromPanic(v)
+//		}
+//	}()
+func FromPanic(v interface{}) error {
+	return fromPanic(v, 3)
+}
+
+//fromPanic returns an error instance of recovered panic value. (internal)
+func fromPanic(v interface{}, depth int) error {
+	if v == nil {
+		return nil
+	}
+	opts := []ErrorContextFunc{withDeferred(), WithContext("panic", v), WithKind(Internal)}
+	if err, ok := v.(error); ok {
+		return newError(ErrPanic, false, depth, append(opts, WithCause(err))...)
+	}
+	return newError(fmt.Errorf("%w: %v", ErrPanic, v), true, depth, opts...)
+}
+
+//Recover function recovers panic and stores an error instance of the panic value (see FromPanic function) to *errp.
+//Recover function must be called directly by defer statement.
+//The error already stored in *errp is replaced. If errp is nil, Recover function panics again with the panic value.
+//
+//	func run() (err error) {
+//		defer errs.Recover(&err)
+//		...
+//	}
+func Recover(errp *error) {
+	v := recover()
+	if v == nil {
+		return
+	}
+	if errp == nil {
+		panic(v)
+	}
+	*errp = fromPanic(v, 3)
+}
+
+//Go function runs fn in a new goroutine with protection by Recover function.
+//The returned channel receives the error returned by fn (or the error of the panic in fn), and is closed after fn returns.
+//Receiving from the channel returns nil if fn succeeds.
+func Go(fn func() error) <-chan error {
+	ch := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		if err := run(fn); err != nil {
+			ch <- err
+		}
+	}()
+	return ch
+}
+
+//run calls fn with protection by Recover function. (internal)
+func run(fn func() error) (err error) {
+	defer Recover(&err)
+	return fn()
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,131 @@
+package errs
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func panicWith(v interface{}) (err error) {
+	defer Recover(&err)
+	panic(v)
+}
+
+func panicNilMap() (err error) {
+	defer Recover(&err)
+	var m map[string]int
+	m["panic"] = 1
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	testCases := []struct {
+		fn    func() error
+		msg   string
+		cause error
+		value interface{}
+		site  string
+	}{
+		{fn: func() error { return panicWith("boom") }, msg: "panic: boom", value: "boom", site: "errs.panicWith"},
+		{fn: func() error { return panicWith(42) }, msg: "panic: 42", value: 42, site: "errs.panicWith"},
+		{fn: func() error { return panicWith(os.ErrInvalid) }, msg: "panic: invalid argument", cause: os.ErrInvalid, value: os.ErrInvalid, site: "errs.panicWith"},
+		{fn: panicNilMap, msg: "panic: assignment to entry in nil map", site: "errs.panicNilMap"},
+	}
+	for _, tc := range testCases {
+		err := tc.fn()
+		if err == nil {
+			t.Errorf("Recover() is <nil>, want \"%v\"", tc.msg)
+			continue
+		}
+		if err.Error() != tc.msg {
+			t.Errorf("Recover() is \"%v\", want \"%v\"", err, tc.msg)
+		}
+		if !errors.Is(err, ErrPanic) {
+			t.Errorf("errors.Is(\"%v\", ErrPanic) is false, want true", err)
+		}
+		if KindOf(err) != Internal {
+			t.Errorf("KindOf(\"%v\") is %v, want %v", err, KindOf(err), Internal)
+		}
+		e := err.(*Error)
+		if tc.cause != nil && e.Cause != tc.cause {
+			t.Errorf("Cause of Recover() is \"%v\", want \"%v\"", e.Cause, tc.cause)
+		}
+		if tc.value != nil && e.Context["panic"] != tc.value {
+			t.Errorf("Context[\"panic\"] of Recover() is %v, want %v", e.Context["panic"], tc.value)
+		}
+		if fn, _ := e.Context["function"].(string); !strings.HasSuffix(fn, tc.site) {
+			t.Errorf("Context[\"function\"] of Recover() is %v, want %v", fn, tc.site)
+		}
+		st := e.StackTrace()
+		if len(st) == 0 || !strings.HasSuffix(st[0].Function, tc.site) {
+			t.Errorf("StackTrace of Recover() is %v, want to start with %v", st, tc.site)
+		}
+	}
+	var rte runtime.Error
+	if err := panicNilMap(); !errors.As(err, &rte) {
+		t.Errorf("errors.As(\"%v\", runtime.Error) is false, want true", err)
+	}
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	run := func() (err error) {
+		defer Recover(&err)
+		return os.ErrNotExist
+	}
+	if err := run(); err != os.ErrNotExist {
+		t.Errorf("Recover() is \"%v\", want \"%v\"", err, os.ErrNotExist)
+	}
+}
+
+func TestFromPanic(t *testing.T) {
+	if err := FromPanic(nil); err != nil {
+		t.Errorf("FromPanic(nil) is \"%v\", want <nil>", err)
+	}
+	err := func() (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = FromPanic(v)
+			}
+		}()
+		panic("boom")
+	}()
+	if err == nil || err.Error() != "panic: boom" {
+		t.Errorf("FromPanic() is \"%v\", want \"panic: boom\"", err)
+	}
+	if fn, _ := err.(*Error).Context["function"].(string); !strings.Contains(fn, "TestFromPanic.func") {
+		t.Errorf("Context[\"function\"] of FromPanic() is %v, want panic site", fn)
+	}
+	//outside of deferred function
+	if fn, _ := FromPanic("value").(*Error).Context["function"].(string); !strings.HasSuffix(fn, "TestFromPanic") {
+		t.Errorf("Context[\"function\"] of FromPanic() is %v, want caller", fn)
+	}
+}
+
+func TestGo(t *testing.T) {
+	if err := <-Go(func() error { return nil }); err != nil {
+		t.Errorf("Go() is \"%v\", want <nil>", err)
+	}
+	if err := <-Go(func() error { return os.ErrInvalid }); err != os.ErrInvalid {
+		t.Errorf("Go() is \"%v\", want \"%v\"", err, os.ErrInvalid)
+	}
+	if err := <-Go(func() error { panic("worker") }); err == nil || err.Error() != "panic: worker" || !Is(err, ErrPanic) {
+		t.Errorf("Go() is \"%v\", want \"panic: worker\"", err)
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
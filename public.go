@@ -0,0 +1,97 @@
+package errs
+
+import (
+	"sync/atomic"
+)
+
+//DefaultPublicMessage is the default generic user-facing message for errors without public message.
+const DefaultPublicMessage = "internal error"
+
+var publicFallback atomic.Pointer[string]
+
+//SetPublicFallback function sets generic user-facing message for errors without public message (see PublicMessage function).
+//SetPublicFallback("") resets the message to DefaultPublicMessage.
+func SetPublicFallback(msg string) {
+	if len(msg) == 0 {
+		publicFallback.Store(nil)
+		return
+	}
+	publicFallback.Store(&msg)
+}
+
+//publicFallbackMessage returns generic user-facing message. (internal)
+func publicFallbackMessage() string {
+	if msg := publicFallback.Load(); msg != nil {
+		return *msg
+	}
+	return DefaultPublicMessage
+}
+
+//WithPublicMessage function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that represents user-facing message.
+//The public message is independent of Error method, which contains internal messages of Err and Cause.
+func WithPublicMessage(msg string) ErrorContextFunc {
+	return func(e *Error) {
+		_ = e.SetPublicMessage(msg)
+	}
+}
+
+//SetPublicMessage method sets user-facing message.
+//SetPublicMessage method panics if the instance is frozen.
+func (e *Error) SetPublicMessage(msg string) *Error {
+	if e == nil {
+		return e
+	}
+	e.mustNotFrozen("SetPublicMessage")
+	e.PublicMessage = msg
+	return e
+}
+
+//LookupPublicMessage function finds user-facing message of err: the outermost public message in err's tree (pre-order traversal),
+//or the default message of the error code (see CodeOf function and RegisterCode function).
+//The message is redacted by the redaction policy (see SetRedactionPolicy function).
+//LookupPublicMessage function returns false if err's tree has neither of them.
+func LookupPublicMessage(err error) (string, bool) {
+	msg := ""
+	walk(err, func(n error) bool {
+		if e, ok := n.(*Error); ok && e != nil && len(e.PublicMessage) > 0 {
+			msg = e.PublicMessage
+			return false
+		}
+		return true
+	})
+	if len(msg) == 0 {
+		if code := CodeOf(err); len(code) > 0 {
+			msg = code.Info().Message
+		}
+	}
+	return redactString(msg), len(msg) > 0
+}
+
+//PublicMessage function returns user-facing message of err: the outermost public message in err's tree,
+//the default message of the error code, or the generic message set by SetPublicFallback function (default is DefaultPublicMessage).
+//PublicMessage function returns empty string if err is nil.
+func PublicMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	if msg, ok := LookupPublicMessage(err); ok {
+		return msg
+	}
+	return publicFallbackMessage()
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
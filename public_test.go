@@ -0,0 +1,85 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestPublicMessage(t *testing.T) {
+	testCases := []struct {
+		err    error
+		public string
+		ok     bool
+		msg    string
+	}{
+		{err: nil, public: "", ok: false, msg: "<nil>"},
+		{err: os.ErrInvalid, public: DefaultPublicMessage, ok: false, msg: "invalid argument"},
+		{err: New("query failed", WithCause(os.ErrInvalid)), public: DefaultPublicMessage, ok: false, msg: "query failed: invalid argument"},
+		{err: New("query failed", WithCause(os.ErrInvalid), WithPublicMessage("try again later")), public: "try again later", ok: true, msg: "query failed: invalid argument"},
+		{err: Wrap(New("query failed", WithPublicMessage("try again later")), WithPublicMessage("user not found")), public: "user not found", ok: true, msg: "query failed"},
+		{err: Wrap(New("query failed", WithPublicMessage("try again later"))), public: "try again later", ok: true, msg: "query failed"},
+		{err: fmt.Errorf("wrapped: %w", New("query failed", WithPublicMessage("try again later"))), public: "try again later", ok: true, msg: "wrapped: query failed"},
+		{err: errors.Join(os.ErrInvalid, New("query failed", WithPublicMessage("try again later"))), public: "try again later", ok: true, msg: "invalid argument\nquery failed"},
+		{err: Wrap(os.ErrInvalid, WithCode(codeTest)), public: "error for test", ok: true, msg: "invalid argument"},
+		{err: Wrap(os.ErrInvalid, WithCode(codeTestNoMsg)), public: DefaultPublicMessage, ok: false, msg: "invalid argument"},
+		{err: Wrap(New("query failed", WithPublicMessage("try again later")), WithCode(codeTest)), public: "try again later", ok: true, msg: "query failed"},
+	}
+	for _, tc := range testCases {
+		if str := PublicMessage(tc.err); str != tc.public {
+			t.Errorf("PublicMessage(\"%v\") is %q, want %q", tc.err, str, tc.public)
+		}
+		if _, ok := LookupPublicMessage(tc.err); ok != tc.ok {
+			t.Errorf("LookupPublicMessage(\"%v\") is %v, want %v", tc.err, ok, tc.ok)
+		}
+		if str := fmt.Sprintf("%v", tc.err); str != tc.msg {
+			t.Errorf("Error() is %q, want %q", str, tc.msg)
+		}
+	}
+}
+
+func TestSetPublicFallback(t *testing.T) {
+	t.Cleanup(func() { SetPublicFallback("") })
+	SetPublicFallback("something went wrong")
+	if str := PublicMessage(os.ErrInvalid); str != "something went wrong" {
+		t.Errorf("PublicMessage() is %q, want %q", str, "something went wrong")
+	}
+	SetPublicFallback("")
+	if str := PublicMessage(os.ErrInvalid); str != DefaultPublicMessage {
+		t.Errorf("PublicMessage() is %q, want %q", str, DefaultPublicMessage)
+	}
+}
+
+func TestPublicMessageJSON(t *testing.T) {
+	err := New("query failed", WithPublicMessage("try again later"), WithoutCaller())
+	want := `{"Type":"*errs.Error","Err":{"Type":"*errors.errorString","Msg":"query failed"},"PublicMessage":"try again later"}`
+	if str := EncodeJSON(err); str != want {
+		t.Errorf("EncodeJSON() is %v, want %v", str, want)
+	}
+	decoded, decErr := DecodeJSON([]byte(want))
+	if decErr != nil {
+		t.Fatalf("DecodeJSON() is \"%v\", want <nil>", decErr)
+	}
+	if str := PublicMessage(decoded); str != "try again later" {
+		t.Errorf("PublicMessage(DecodeJSON()) is %q, want %q", str, "try again later")
+	}
+	if c := err.(*Error).Freeze().Clone().SetPublicMessage("other"); c.PublicMessage != "other" || err.(*Error).PublicMessage != "try again later" {
+		t.Errorf("SetPublicMessage() of copy of frozen instance is %q, want copy", c.PublicMessage)
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
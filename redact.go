@@ -0,0 +1,189 @@
+package errs
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sync/atomic"
+)
+
+const (
+	//RedactedString is the replacement string of sensitive values.
+	RedactedString = "[REDACTED]"
+)
+
+//Redacted type is a wrapper of sensitive value.
+//Redacted value is output as RedactedString by every output path (fmt, JSON and slog).
+//The original value is available by Value method.
+type Redacted struct {
+	value interface{}
+}
+
+var _ fmt.Stringer = Redacted{}           //Redacted type is compatible with fmt.Stringer interface
+var _ fmt.GoStringer = Redacted{}         //Redacted type is compatible with fmt.GoStringer interface
+var _ fmt.Formatter = Redacted{}          //Redacted type is compatible with fmt.Formatter interface
+var _ json.Marshaler = Redacted{}         //Redacted type is compatible with json.Marshaler interface
+var _ slog.LogValuer = Redacted{}         //Redacted type is compatible with slog.LogValuer interface
+var _ encoding.TextMarshaler = Redacted{} //Redacted type is compatible with encoding.TextMarshaler interface
+
+//Redact function returns Redacted value of sensitive value.
+func Redact(value interface{}) Redacted {
+	return Redacted{value: value}
+}
+
+//Value method returns the original value.
+func (r Redacted) Value() interface{} {
+	return r.value
+}
+
+//String method returns RedactedString.
+//This method is a implementation of fmt.Stringer interface.
+func (r Redacted) String() string {
+	return RedactedString
+}
+
+//GoString method returns RedactedString.
+//This method is a implementation of fmt.GoStringer interface.
+func (r Redacted) GoString() string {
+	return RedactedString
+}
+
+//Format method returns RedactedString for every verb.
+//This method is a implementation of fmt.Formatter interface.
+func (r Redacted) Format(s fmt.State, verb rune) {
+	_, _ = s.Write([]byte(RedactedString))
+}
+
+//MarshalJSON method returns RedactedString with JSON format.
+//This method is implementation of json.Marshaler interface.
+func (r Redacted) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RedactedString)
+}
+
+//MarshalText method returns RedactedString.
+//This method is implementation of encoding.TextMarshaler interface.
+func (r Redacted) MarshalText() ([]byte, error) {
+	return []byte(RedactedString), nil
+}
+
+//LogValue method returns RedactedString as slog.Value.
+//This method is a implementation of slog.LogValuer interface.
+func (r Redacted) LogValue() slog.Value {
+	return slog.StringValue(RedactedString)
+}
+
+//WithSecret function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that represents sensitive context (key/value) data.
+//The value is stored as Redacted value.
+func WithSecret(name string, value interface{}) ErrorContextFunc {
+	return WithContext(name, Redact(value))
+}
+
+//RedactionPolicy type is global policy of redaction.
+type RedactionPolicy struct {
+	//Keys is patterns of context keys. Values of matched keys are replaced by RedactedString.
+	Keys []*regexp.Regexp
+	//Values is patterns of sensitive strings. Matched substrings in messages and string context values are replaced by RedactedString.
+	Values []*regexp.Regexp
+}
+
+var redactionPolicy atomic.Pointer[RedactionPolicy]
+
+//SetRedactionPolicy function sets global policy of redaction.
+//The policy is applied to every output path (Error, GoString, JSON and slog) of Error instances, including public messages.
+//SetRedactionPolicy(nil) clears the policy.
+func SetRedactionPolicy(policy *RedactionPolicy) {
+	if policy == nil {
+		redactionPolicy.Store(nil)
+		return
+	}
+	p := &RedactionPolicy{
+		Keys:   append([]*regexp.Regexp{}, policy.Keys...),
+		Values: append([]*regexp.Regexp{}, policy.Values...),
+	}
+	redactionPolicy.Store(p)
+}
+
+//redactKey reports whether the context key matches key patterns of the policy. (internal)
+func (p *RedactionPolicy) redactKey(name string) bool {
+	if p == nil {
+		return false
+	}
+	for _, re := range p.Keys {
+		if re != nil && re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+//redactString returns string replaced sensitive substrings. (internal)
+func (p *RedactionPolicy) redactString(s string) string {
+	if p == nil {
+		return s
+	}
+	for _, re := range p.Values {
+		if re != nil {
+			s = re.ReplaceAllLiteralString(s, RedactedString)
+		}
+	}
+	return s
+}
+
+//redactString returns string replaced sensitive substrings by global policy. (internal)
+func redactString(s string) string {
+	return redactionPolicy.Load().redactString(s)
+}
+
+//RedactValue function returns the context value redacted by Redacted type and global policy.
+func RedactValue(name string, value interface{}) interface{} {
+	return redactionPolicy.Load().redactValue(name, value)
+}
+
+//redactValue returns the context value redacted by Redacted type and the policy. (internal)
+func (p *RedactionPolicy) redactValue(name string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case Redacted, *Redacted:
+		return RedactedString
+	case string:
+		if p.redactKey(name) {
+			return RedactedString
+		}
+		return p.redactString(v)
+	}
+	if p.redactKey(name) {
+		return RedactedString
+	}
+	return value
+}
+
+//SafeContext method returns copy of context redacted by Redacted type and global policy.
+//Use Context field directly to get original values.
+func (e *Error) SafeContext() map[string]interface{} {
+	if e == nil || e.Context == nil {
+		return nil
+	}
+	p := redactionPolicy.Load()
+	ctx := make(map[string]interface{}, len(e.Context))
+	for k, v := range e.Context {
+		ctx[k] = p.redactValue(k, v)
+	}
+	return ctx
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
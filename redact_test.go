@@ -0,0 +1,132 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedacted(t *testing.T) {
+	r := Redact("secret-token")
+	testCases := []struct {
+		str string
+	}{
+		{str: fmt.Sprint(r)},
+		{str: fmt.Sprintf("%v", r)},
+		{str: fmt.Sprintf("%+v", r)},
+		{str: fmt.Sprintf("%#v", r)},
+		{str: fmt.Sprintf("%s", r)},
+		{str: fmt.Sprintf("%q", r)},
+		{str: fmt.Sprintf("%d", r)},
+		{str: r.String()},
+		{str: r.GoString()},
+		{str: r.LogValue().String()},
+	}
+	for _, tc := range testCases {
+		if tc.str != RedactedString {
+			t.Errorf("Redacted value is %v, want %v", tc.str, RedactedString)
+		}
+	}
+	if b, err := json.Marshal(r); err != nil || string(b) != `"[REDACTED]"` {
+		t.Errorf("json.Marshal(Redacted) is %s (%v), want %v", b, err, `"[REDACTED]"`)
+	}
+	if b, err := json.Marshal(map[Redacted]int{r: 1}); err != nil || string(b) != `{"[REDACTED]":1}` {
+		t.Errorf("json.Marshal(map[Redacted]int) is %s (%v), want %v", b, err, `{"[REDACTED]":1}`)
+	}
+	if v := r.Value(); v != "secret-token" {
+		t.Errorf("Redacted.Value() is %v, want %v", v, "secret-token")
+	}
+}
+
+func outputsOf(err error) []string {
+	buf := &bytes.Buffer{}
+	slog.New(slog.NewJSONHandler(buf, nil)).Error("test", slog.Any("error", err))
+	return []string{
+		err.Error(),
+		fmt.Sprintf("%v", err),
+		fmt.Sprintf("%#v", err),
+		fmt.Sprintf("%+v", err),
+		EncodeJSON(err),
+		buf.String(),
+	}
+}
+
+func TestWithSecret(t *testing.T) {
+	err := New("authentication error", WithSecret("token", "secret-token"), WithContext("user", "alice"))
+	for _, str := range outputsOf(err) {
+		if strings.Contains(str, "secret-token") {
+			t.Errorf("output of \"%v\" leaks secret: %v", err, str)
+		}
+	}
+	for _, str := range outputsOf(err)[2:] {
+		if !strings.Contains(str, RedactedString) || !strings.Contains(str, "alice") {
+			t.Errorf("output of \"%v\" is %v, want redacted token and user", err, str)
+		}
+	}
+	e := err.(*Error)
+	if r, ok := e.Context["token"].(Redacted); !ok || r.Value() != "secret-token" {
+		t.Errorf("Context[\"token\"] is %v, want original value", e.Context["token"])
+	}
+	if v := RedactValue("token", e.Context["token"]); v != RedactedString {
+		t.Errorf("RedactValue() is %v, want %v", v, RedactedString)
+	}
+}
+
+func TestRedactionPolicy(t *testing.T) {
+	SetRedactionPolicy(&RedactionPolicy{
+		Keys:   []*regexp.Regexp{regexp.MustCompile(`(?i)password|secret`)},
+		Values: []*regexp.Regexp{regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`)},
+	})
+	t.Cleanup(func() { SetRedactionPolicy(nil) })
+
+	err := Wrap(
+		New("invalid user alice@example.com", WithContext("password", "p@ssw0rd"), WithContext("mail", "bob@example.com")),
+		WithContext("DB_SECRET", 12345),
+		WithContext("user", "alice"),
+		WithPublicMessage("contact carol@example.com"),
+	)
+	for _, str := range append(outputsOf(err), PublicMessage(err)) {
+		for _, secret := range []string{"alice@example.com", "p@ssw0rd", "bob@example.com", "12345", "carol@example.com"} {
+			if strings.Contains(str, secret) {
+				t.Errorf("output of error leaks %v: %v", secret, str)
+			}
+		}
+	}
+	if str := err.Error(); str != "invalid user [REDACTED]" {
+		t.Errorf("Error() is %v, want %v", str, "invalid user [REDACTED]")
+	}
+	if str := trimStackTrace(EncodeJSON(err)); !strings.Contains(str, `"Context":{"DB_SECRET":"[REDACTED]","function":"github.com/spiegel-im-spiegel/errs.TestRedactionPolicy","user":"alice"}`) {
+		t.Errorf("EncodeJSON() is %v, want redacted context", str)
+	}
+	if str := PublicMessage(err); str != "contact [REDACTED]" {
+		t.Errorf("PublicMessage() is %v, want %v", str, "contact [REDACTED]")
+	}
+	inner := Unwrap(err).(*Error)
+	if inner.Context["password"] != "p@ssw0rd" || inner.Context["mail"] != "bob@example.com" {
+		t.Errorf("Context is %v, want original values", inner.Context)
+	}
+
+	SetRedactionPolicy(nil)
+	if str := err.Error(); str != "invalid user alice@example.com" {
+		t.Errorf("Error() is %v, want %v", str, "invalid user alice@example.com")
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
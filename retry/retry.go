@@ -0,0 +1,247 @@
+// Package retry executes operations with exponential backoff and jitter,
+// respecting retryability of errors classified by errs.IsRetryable function.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/spiegel-im-spiegel/errs"
+)
+
+const (
+	//DefaultMaxAttempts is default maximum number of attempts.
+	DefaultMaxAttempts = 5
+	//DefaultInitialInterval is default interval before the second attempt.
+	DefaultInitialInterval = 100 * time.Millisecond
+	//DefaultMaxInterval is default maximum interval between attempts.
+	DefaultMaxInterval = 10 * time.Second
+	//DefaultMultiplier is default growth factor of interval.
+	DefaultMultiplier = 2.0
+	//DefaultJitter is default randomization factor of interval.
+	DefaultJitter = 0.2
+)
+
+//ErrExhausted is cause of error returned by Do method if attempts are exhausted.
+var ErrExhausted = errors.New("retry attempts exhausted")
+
+//ErrAborted is cause of error returned by Do method if an attempt fails with non-retryable error or context is done.
+var ErrAborted = errors.New("retry aborted")
+
+//Clock interface is source of time for Retrier.
+//Replace it by WithClock function in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+//systemClock type is Clock by the time package. (internal)
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+//SystemClock is Clock by the time package.
+var SystemClock Clock = systemClock{}
+
+//Retrier type is executor of operations with retries.
+type Retrier struct {
+	maxAttempts int
+	maxElapsed  time.Duration
+	initial     time.Duration
+	max         time.Duration
+	multiplier  float64
+	jitter      float64
+	clock       Clock
+	random      func() float64
+	retryable   func(error) bool
+}
+
+//Option type is functional option for NewRetrier function.
+type Option func(*Retrier)
+
+//WithMaxAttempts function returns Option function value.
+//This function is used in NewRetrier function that represents maximum number of attempts (including the first attempt).
+func WithMaxAttempts(n int) Option {
+	return func(r *Retrier) {
+		if n > 0 {
+			r.maxAttempts = n
+		}
+	}
+}
+
+//WithMaxElapsedTime function returns Option function value.
+//This function is used in NewRetrier function that represents limit of elapsed time from the first attempt.
+//Retrier gives up if the next attempt would start after the limit. (Default is no limit.)
+func WithMaxElapsedTime(d time.Duration) Option {
+	return func(r *Retrier) {
+		r.maxElapsed = d
+	}
+}
+
+//WithBackoff function returns Option function value.
+//This function is used in NewRetrier function that represents exponential backoff:
+//interval before the second attempt, maximum interval (0 or negative value is no limit), and growth factor of interval.
+func WithBackoff(initial, max time.Duration, multiplier float64) Option {
+	return func(r *Retrier) {
+		r.initial = initial
+		r.max = max
+		if multiplier >= 1 {
+			r.multiplier = multiplier
+		}
+	}
+}
+
+//WithJitter function returns Option function value.
+//This function is used in NewRetrier function that represents randomization factor (0 to 1) of interval.
+//Every interval is randomized in range [interval * (1 - factor), interval * (1 + factor)].
+func WithJitter(factor float64) Option {
+	return func(r *Retrier) {
+		switch {
+		case factor < 0:
+			r.jitter = 0
+		case factor > 1:
+			r.jitter = 1
+		default:
+			r.jitter = factor
+		}
+	}
+}
+
+//WithClock function returns Option function value.
+//This function is used in NewRetrier function that represents source of time (fake clock in tests).
+func WithClock(c Clock) Option {
+	return func(r *Retrier) {
+		if c != nil {
+			r.clock = c
+		}
+	}
+}
+
+//WithRandom function returns Option function value.
+//This function is used in NewRetrier function that represents source of random numbers in [0, 1) for jitter.
+func WithRandom(random func() float64) Option {
+	return func(r *Retrier) {
+		if random != nil {
+			r.random = random
+		}
+	}
+}
+
+//WithClassifier function returns Option function value.
+//This function is used in NewRetrier function that represents classifier of retryable errors.
+//(Default is errs.IsRetryable function.)
+func WithClassifier(retryable func(error) bool) Option {
+	return func(r *Retrier) {
+		if retryable != nil {
+			r.retryable = retryable
+		}
+	}
+}
+
+//NewRetrier function returns Retrier instance.
+func NewRetrier(opts ...Option) *Retrier {
+	r := &Retrier{
+		maxAttempts: DefaultMaxAttempts,
+		initial:     DefaultInitialInterval,
+		max:         DefaultMaxInterval,
+		multiplier:  DefaultMultiplier,
+		jitter:      DefaultJitter,
+		clock:       SystemClock,
+		random:      rand.Float64, //nolint:gosec // jitter does not need cryptographic randomness
+		retryable:   errs.IsRetryable,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+var defaultRetrier = NewRetrier()
+
+//Do method calls fn until it succeeds, fails with non-retryable error, attempts are exhausted, or ctx is done.
+//Interval between attempts grows exponentially with jitter, and is extended to the delay set by errs.WithRetryAfter function.
+//If fn does not succeed, Do method returns *errs.Error instance that has ErrExhausted or ErrAborted,
+//errors of all attempts (and ctx.Err()) as causes, and the number of attempts as "attempts" context.
+//The returned error is not retryable, to avoid nested retries.
+func (r *Retrier) Do(ctx context.Context, fn func(context.Context) error) error {
+	start := r.clock.Now()
+	interval := r.initial
+	attemptErrs := []error{}
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		attemptErrs = append(attemptErrs, err)
+		if !r.retryable(err) {
+			return giveUp(ErrAborted, attempt, attemptErrs)
+		}
+		if attempt >= r.maxAttempts {
+			return giveUp(ErrExhausted, attempt, attemptErrs)
+		}
+		wait := r.randomize(interval)
+		if d, ok := errs.RetryAfter(err); ok && d > wait {
+			wait = d
+		}
+		if r.maxElapsed > 0 && r.clock.Now().Add(wait).Sub(start) > r.maxElapsed {
+			return giveUp(ErrExhausted, attempt, attemptErrs)
+		}
+		select {
+		case <-ctx.Done():
+			return giveUp(ErrAborted, attempt, append(attemptErrs, ctx.Err()))
+		case <-r.clock.After(wait):
+		}
+		interval = r.next(interval)
+	}
+}
+
+//randomize method returns interval with jitter. (internal)
+func (r *Retrier) randomize(interval time.Duration) time.Duration {
+	if r.jitter == 0 || interval <= 0 {
+		return interval
+	}
+	delta := r.jitter * float64(interval)
+	return time.Duration(float64(interval) - delta + r.random()*2*delta)
+}
+
+//next method returns interval before the next attempt. (internal)
+//Interval is capped by maximum interval if it is set.
+func (r *Retrier) next(interval time.Duration) time.Duration {
+	next := float64(interval) * r.multiplier
+	if r.max > 0 && next >= float64(r.max) {
+		return r.max
+	}
+	if next >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(next)
+}
+
+//giveUp returns error aggregating all attempts. (internal)
+func giveUp(err error, attempts int, attemptErrs []error) error {
+	return errs.WrapSkip(err, 1, errs.WithCauses(attemptErrs...), errs.WithContext("attempts", attempts), errs.WithRetryable(false))
+}
+
+//Do function calls fn with retries by default Retrier.
+func Do(ctx context.Context, fn func(context.Context) error) error {
+	return defaultRetrier.Do(ctx, fn)
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,158 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spiegel-im-spiegel/errs"
+)
+
+//fakeClock is Clock that advances immediately and records waits.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+	block bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	if !c.block {
+		c.now = c.now.Add(d)
+		ch <- c.now
+	}
+	return ch
+}
+
+//failures returns operation that fails with errs in order, and then succeeds.
+func failures(errList ...error) (func(context.Context) error, *int) {
+	calls := 0
+	return func(context.Context) error {
+		calls++
+		if calls <= len(errList) {
+			return errList[calls-1]
+		}
+		return nil
+	}, &calls
+}
+
+func TestDo(t *testing.T) {
+	errTemp := errs.New("temporary", errs.WithRetryable(true))
+	errPerm := errs.New("permanent")
+	testCases := []struct {
+		opts     []Option
+		errs     []error
+		calls    int
+		waits    []time.Duration
+		cause    error
+		attempts int
+	}{
+		{errs: nil, calls: 1, waits: nil},
+		{errs: []error{errTemp, syscall.ECONNRESET}, calls: 3, waits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}},
+		{errs: []error{errPerm}, calls: 1, waits: nil, cause: ErrAborted, attempts: 1},
+		{errs: []error{errTemp, errPerm}, calls: 2, waits: []time.Duration{100 * time.Millisecond}, cause: ErrAborted, attempts: 2},
+		{opts: []Option{WithMaxAttempts(3)}, errs: []error{errTemp, errTemp, errTemp, errTemp}, calls: 3, waits: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, cause: ErrExhausted, attempts: 3},
+		{opts: []Option{WithBackoff(time.Second, 3*time.Second, 2)}, errs: []error{errTemp, errTemp, errTemp, errTemp}, calls: 5, waits: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}},
+		{opts: []Option{WithBackoff(time.Second, 0, 2)}, errs: []error{errTemp, errTemp, errTemp, errTemp}, calls: 5, waits: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second}},
+		{opts: []Option{WithBackoff(time.Second, time.Minute, 2), WithMaxElapsedTime(5 * time.Second)}, errs: []error{errTemp, errTemp, errTemp, errTemp}, calls: 3, waits: []time.Duration{time.Second, 2 * time.Second}, cause: ErrExhausted, attempts: 3},
+		{errs: []error{errs.Wrap(errTemp, errs.WithRetryAfter(5*time.Second)), errTemp}, calls: 3, waits: []time.Duration{5 * time.Second, 200 * time.Millisecond}},
+		{opts: []Option{WithClassifier(func(error) bool { return true })}, errs: []error{errPerm}, calls: 2, waits: []time.Duration{100 * time.Millisecond}},
+	}
+	for _, tc := range testCases {
+		clock := &fakeClock{}
+		fn, calls := failures(tc.errs...)
+		err := NewRetrier(append([]Option{WithClock(clock), WithJitter(0)}, tc.opts...)...).Do(context.Background(), fn)
+		if *calls != tc.calls {
+			t.Errorf("number of attempts for %v is %v, want %v", tc.errs, *calls, tc.calls)
+		}
+		if !reflect.DeepEqual(clock.waits, tc.waits) {
+			t.Errorf("waits for %v is %v, want %v", tc.errs, clock.waits, tc.waits)
+		}
+		if tc.cause == nil {
+			if err != nil {
+				t.Errorf("Do() for %v is \"%v\", want <nil>", tc.errs, err)
+			}
+			continue
+		}
+		if !errs.Is(err, tc.cause) {
+			t.Errorf("Do() for %v is \"%v\", want \"%v\"", tc.errs, err, tc.cause)
+		}
+		var e *errs.Error
+		if !errs.As(err, &e) || e.Context["attempts"] != tc.attempts {
+			t.Errorf("attempts of Do() for %v is %v, want %v", tc.errs, e, tc.attempts)
+		}
+		for _, ee := range tc.errs[:tc.attempts] {
+			if !errs.Is(err, ee) {
+				t.Errorf("Do() for %v is \"%v\", want to have \"%v\"", tc.errs, err, ee)
+			}
+		}
+		if errs.IsRetryable(err) {
+			t.Errorf("IsRetryable(Do()) for %v is true, want false", tc.errs)
+		}
+	}
+}
+
+func TestDoJitter(t *testing.T) {
+	testCases := []struct {
+		random float64
+		wait   time.Duration
+	}{
+		{random: 0, wait: 80 * time.Millisecond},
+		{random: 0.5, wait: 100 * time.Millisecond},
+		{random: 0.75, wait: 110 * time.Millisecond},
+	}
+	for _, tc := range testCases {
+		clock := &fakeClock{}
+		fn, _ := failures(syscall.ECONNREFUSED)
+		random := tc.random
+		if err := NewRetrier(WithClock(clock), WithRandom(func() float64 { return random })).Do(context.Background(), fn); err != nil {
+			t.Errorf("Do() is \"%v\", want <nil>", err)
+		}
+		if len(clock.waits) != 1 || clock.waits[0] != tc.wait {
+			t.Errorf("waits with random %v is %v, want [%v]", tc.random, clock.waits, tc.wait)
+		}
+	}
+}
+
+func TestDoCanceled(t *testing.T) {
+	clock := &fakeClock{block: true}
+	ctx, cancel := context.WithCancel(context.Background())
+	fn := func(context.Context) error {
+		cancel()
+		return os.NewSyscallError("read", syscall.ECONNRESET)
+	}
+	err := NewRetrier(WithClock(clock)).Do(ctx, fn)
+	if !errs.Is(err, ErrAborted) || !errors.Is(err, context.Canceled) || !errs.Is(err, syscall.ECONNRESET) {
+		t.Errorf("Do() is \"%v\", want \"%v\"", err, ErrAborted)
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,96 @@
+package errs
+
+import (
+	"time"
+)
+
+//Keys of context information for retryability.
+var (
+	keyRetryable  = NewKey[bool]("retryable")
+	keyRetryAfter = NewKey[time.Duration]("retry_after")
+)
+
+//WithRetryable function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that represents whether the operation can be retried.
+//The explicit classification takes precedence over the classification of errors in the cause chain.
+func WithRetryable(retryable bool) ErrorContextFunc {
+	return WithValue(keyRetryable, retryable)
+}
+
+//WithRetryAfter function returns ErrorContextFunc function value.
+//This function is used in New and Wrap functions that represents delay before the next attempt.
+//The error is retryable unless WithRetryable(false) is also specified.
+func WithRetryAfter(d time.Duration) ErrorContextFunc {
+	return WithValue(keyRetryAfter, d)
+}
+
+//RetryAfter function finds the first delay set by WithRetryAfter function in err's tree.
+func RetryAfter(err error) (time.Duration, bool) {
+	return Value(err, keyRetryAfter)
+}
+
+//IsRetryable function reports whether the operation failed with err can be retried.
+//The first decisive error in err's tree (pre-order traversal) wins:
+//
+//   - Error instance with WithRetryable or WithRetryAfter option
+//   - error of Timeout or Unavailable kind (or their descendant kinds) is retryable, and error of Canceled kind is not (see KindOf function)
+//   - error with Temporary method returning true (e.g. net.Error)
+//   - syscall.Errno of transient network failure (ECONNREFUSED, ECONNRESET and so on)
+//
+//Note that context.DeadlineExceeded and errors with Timeout method returning true are Timeout kind,
+//and context.Canceled is Canceled kind.
+//IsRetryable function returns false if err's tree has no decisive error.
+func IsRetryable(err error) bool {
+	retryable := false
+	walk(err, func(n error) bool {
+		var ok bool
+		retryable, ok = retryableNode(n)
+		return !ok
+	})
+	return retryable
+}
+
+//retryableNode returns retryability of the error (not including its tree), and whether it is decisive. (internal)
+func retryableNode(err error) (bool, bool) {
+	if e, ok := err.(*Error); ok {
+		if e == nil {
+			return false, false
+		}
+		if v, ok := e.Context[keyRetryable.name].(bool); ok {
+			return v, true
+		}
+		if _, ok := e.Context[keyRetryAfter.name].(time.Duration); ok {
+			return true, true
+		}
+	}
+	if kind := kindOfNode(err); kind != nil {
+		switch {
+		case kind.in(Timeout), kind.in(Unavailable):
+			return true, true
+		case kind.in(Canceled):
+			return false, true
+		}
+	}
+	if t, ok := err.(interface{ Temporary() bool }); ok && t.Temporary() {
+		return true, true
+	}
+	if retryableErrno(err) {
+		return true, true
+	}
+	return false, false
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,111 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+var kindThrottled = NewKind("test.Throttled", Unavailable)
+
+//temporaryError is error with Temporary method.
+type temporaryError bool
+
+func (e temporaryError) Error() string   { return fmt.Sprintf("temporary: %v", bool(e)) }
+func (e temporaryError) Temporary() bool { return bool(e) }
+
+func TestIsRetryable(t *testing.T) {
+	testCases := []struct {
+		err error
+		res bool
+	}{
+		{err: nil, res: false},
+		{err: os.ErrInvalid, res: false},
+		{err: New("error"), res: false},
+		{err: New("error", WithRetryable(true)), res: true},
+		{err: Wrap(New("error", WithRetryable(true)), WithRetryable(false)), res: false},
+		{err: Wrap(context.DeadlineExceeded, WithRetryable(false)), res: false},
+		{err: New("error", WithRetryAfter(time.Second)), res: true},
+		{err: New("error", WithRetryAfter(time.Second), WithRetryable(false)), res: false},
+		{err: Wrap(context.DeadlineExceeded), res: true},
+		{err: fmt.Errorf("wrapped: %w", context.DeadlineExceeded), res: true},
+		{err: Wrap(context.Canceled), res: false},
+		{err: New("error", WithCause(context.Canceled), WithRetryable(true)), res: true},
+		{err: New("error", WithKind(kindThrottled)), res: true},
+		{err: New("error", WithKind(Timeout)), res: true},
+		{err: New("error", WithKind(NotFound), WithCause(syscall.ECONNRESET)), res: true},
+		{err: Wrap(temporaryError(true)), res: true},
+		{err: Wrap(temporaryError(false)), res: false},
+		{err: Wrap(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), res: true},
+		{err: &net.DNSError{Err: "timeout", Name: "example.com", IsTimeout: true}, res: true},
+		{err: Wrap(syscall.EAGAIN), res: true},
+		{err: Wrap(syscall.ENOENT), res: false},
+		{err: errors.Join(os.ErrInvalid, syscall.ECONNRESET), res: true},
+	}
+	for _, tc := range testCases {
+		if ok := IsRetryable(tc.err); ok != tc.res {
+			t.Errorf("IsRetryable(\"%v\") is %v, want %v", tc.err, ok, tc.res)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	testCases := []struct {
+		err error
+		d   time.Duration
+		ok  bool
+	}{
+		{err: nil, d: 0, ok: false},
+		{err: New("error"), d: 0, ok: false},
+		{err: New("error", WithRetryAfter(time.Second)), d: time.Second, ok: true},
+		{err: Wrap(New("error", WithRetryAfter(time.Second)), WithRetryAfter(time.Minute)), d: time.Minute, ok: true},
+	}
+	for _, tc := range testCases {
+		if d, ok := RetryAfter(tc.err); d != tc.d || ok != tc.ok {
+			t.Errorf("RetryAfter(\"%v\") is (%v, %v), want (%v, %v)", tc.err, d, ok, tc.d, tc.ok)
+		}
+	}
+}
+
+func TestRetryableKeys(t *testing.T) {
+	//keys of the same name in other packages do not collide
+	if name := keyRetryable.Name(); name != "github.com/spiegel-im-spiegel/errs.retryable" {
+		t.Errorf("Name() is %q, want %q", name, "github.com/spiegel-im-spiegel/errs.retryable")
+	}
+	src := EncodeJSON(New("error", WithContext("retryable", true), WithoutCaller()))
+	if err, e := DecodeJSON([]byte(src)); e != nil || IsRetryable(err) {
+		t.Errorf("IsRetryable(DecodeJSON(%v)) is true (%v), want false", src, e)
+	}
+
+	src = EncodeJSON(New("error", WithRetryAfter(time.Second), WithRetryable(true)))
+	err, e := DecodeJSON([]byte(src))
+	if e != nil {
+		t.Fatalf("DecodeJSON(%v) is \"%v\", want <nil>", src, e)
+	}
+	if d, ok := RetryAfter(err); d != time.Second || !ok {
+		t.Errorf("RetryAfter(DecodeJSON(%v)) is (%v, %v), want (%v, %v)", src, d, ok, time.Second, true)
+	}
+	if !IsRetryable(err) {
+		t.Errorf("IsRetryable(DecodeJSON(%v)) is false, want true", src)
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
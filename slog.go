@@ -0,0 +1,165 @@
+package errs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var _ slog.LogValuer = (*Error)(nil) //Error type is compatible with slog.LogValuer interface
+
+//LogValue method returns slog.Value of Error instance.
+//The value is a group containing msg, type, code, kind, message_id, public_message, template, context attributes and nested err/cause groups.
+//This method is a implementation of slog.LogValuer interface.
+func (e *Error) LogValue() slog.Value {
+	return LogValue(e)
+}
+
+//LogValue function returns slog.Value of error instance.
+//The value is a group containing msg, type and nested cause groups.
+//Cyclic error is a group with type "errs.cycle", and error deeper than MaxDepth is a group with truncated attribute.
+func LogValue(err error) slog.Value {
+	return newGuard().logValue(err)
+}
+
+//logValue method returns slog.Value of error instance with guard. (internal)
+func (g *guard) logValue(err error) slog.Value {
+	if err == nil {
+		return slog.StringValue(nilAngleString)
+	}
+	switch g.enter(err) {
+	case guardCycle:
+		return slog.GroupValue(slog.String("type", cycleTypeName))
+	case guardTruncated:
+		return slog.GroupValue(slog.Bool("truncated", true))
+	}
+	defer g.leave()
+	attrs := []slog.Attr{
+		slog.String("msg", redactString(errorMessage(err))),
+		slog.String("type", fmt.Sprintf("%T", err)),
+	}
+	if e, ok := err.(*Error); ok {
+		if e == nil {
+			return slog.StringValue(nilAngleString)
+		}
+		if len(e.Code) > 0 {
+			attrs = append(attrs, slog.String("code", string(e.Code)))
+		}
+		if e.Kind != nil {
+			attrs = append(attrs, slog.String("kind", e.Kind.Name()))
+		}
+		if len(e.MessageID) > 0 {
+			attrs = append(attrs, slog.String("message_id", e.MessageID))
+		}
+		if len(e.PublicMessage) > 0 {
+			attrs = append(attrs, slog.String("public_message", redactString(e.PublicMessage)))
+		}
+		if t, ok := e.Err.(*messageTemplate); ok && t != nil {
+			attrs = append(attrs, slog.String("template", t.tmpl))
+		}
+		if len(e.Context) > 0 {
+			attrs = append(attrs, slog.Attr{Key: "context", Value: contextLogValue(e.SafeContext())})
+		}
+	}
+	causes := []slog.Attr{}
+	for _, b := range branchesOf(err) {
+		if b.name == "causes" {
+			causes = append(causes, slog.Attr{Key: strconv.Itoa(b.index), Value: g.logValue(b.err)})
+		} else {
+			attrs = append(attrs, slog.Attr{Key: b.name, Value: g.logValue(b.err)})
+		}
+	}
+	if len(causes) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "causes", Value: slog.GroupValue(causes...)})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+//contextLogValue returns slog.Value of context map. (internal)
+func contextLogValue(ctx map[string]interface{}) slog.Value {
+	attrs := make([]slog.Attr, 0, len(ctx))
+	for k, v := range ctx {
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	slices.SortFunc(attrs, func(a, b slog.Attr) int { return strings.Compare(a.Key, b.Key) })
+	return slog.GroupValue(attrs...)
+}
+
+//SlogHandler type is a wrapping slog.Handler.
+//SlogHandler expands error attributes anywhere in a record by LogValue function.
+type SlogHandler struct {
+	handler slog.Handler
+}
+
+var _ slog.Handler = (*SlogHandler)(nil) //SlogHandler type is compatible with slog.Handler interface
+
+//NewSlogHandler function returns SlogHandler instance wrapping h.
+func NewSlogHandler(h slog.Handler) *SlogHandler {
+	return &SlogHandler{handler: h}
+}
+
+//Enabled method reports whether the wrapped handler handles records at the given level.
+func (h *SlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+//Handle method expands error attributes in the record and passes it to the wrapped handler.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	nr := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		nr.AddAttrs(expandErrorAttr(a))
+		return true
+	})
+	return h.handler.Handle(ctx, nr)
+}
+
+//WithAttrs method returns a new SlogHandler whose attributes consists of h's attributes followed by attrs.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		expanded = append(expanded, expandErrorAttr(a))
+	}
+	return &SlogHandler{handler: h.handler.WithAttrs(expanded)}
+}
+
+//WithGroup method returns a new SlogHandler with the given group appended to h's existing groups.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	return &SlogHandler{handler: h.handler.WithGroup(name)}
+}
+
+//expandErrorAttr returns attribute expanded error values. (internal)
+func expandErrorAttr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return slog.Attr{Key: a.Key, Value: LogValue(err)}
+		}
+	case slog.KindGroup:
+		group := v.Group()
+		attrs := make([]slog.Attr, 0, len(group))
+		for _, ga := range group {
+			attrs = append(attrs, expandErrorAttr(ga))
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,92 @@
+package errs
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func newTestLogger(buf *bytes.Buffer, wrap bool) *slog.Logger {
+	var h slog.Handler = slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	if wrap {
+		h = NewSlogHandler(h)
+	}
+	return slog.New(h)
+}
+
+func TestLogValue(t *testing.T) {
+	testCases := []struct {
+		err error
+		log string
+	}{
+		{err: nil, log: `{"level":"ERROR","msg":"test","error":"<nil>"}`},
+		{err: nilValueErr, log: `{"level":"ERROR","msg":"test","error":"<nil>"}`},
+		{err: New("error", WithContext("foo", "bar"), WithContext("num", 1), WithCode(codeTest)), log: `{"level":"ERROR","msg":"test","error":{"msg":"error","type":"*errs.Error","code":"TEST-001","context":{"foo":"bar","function":"github.com/spiegel-im-spiegel/errs.TestLogValue","num":1}}}`},
+		{err: New("wrapped error", WithCause(os.ErrInvalid), WithContext("foo", "bar")), log: `{"level":"ERROR","msg":"test","error":{"msg":"wrapped error: invalid argument","type":"*errs.Error","context":{"foo":"bar","function":"github.com/spiegel-im-spiegel/errs.TestLogValue"},"cause":{"msg":"invalid argument","type":"*errors.errorString"}}}`},
+		{err: Wrap(fmt.Errorf("error: %w", os.ErrInvalid)), log: `{"level":"ERROR","msg":"test","error":{"msg":"error: invalid argument","type":"*errs.Error","context":{"function":"github.com/spiegel-im-spiegel/errs.TestLogValue"},"err":{"msg":"error: invalid argument","type":"*fmt.wrapError","cause":{"msg":"invalid argument","type":"*errors.errorString"}}}}`},
+		{err: Join(os.ErrInvalid, os.ErrExist), log: `{"level":"ERROR","msg":"test","error":{"msg":"invalid argument\nfile already exists","type":"*errs.Error","context":{"function":"github.com/spiegel-im-spiegel/errs.TestLogValue"},"err":{"msg":"invalid argument\nfile already exists","type":"*errors.joinError","causes":{"0":{"msg":"invalid argument","type":"*errors.errorString"},"1":{"msg":"file already exists","type":"*errors.errorString"}}}}}`},
+	}
+
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		newTestLogger(buf, false).Error("test", slog.Any("error", LogValue(tc.err)))
+		if str := strings.TrimSpace(buf.String()); str != tc.log {
+			t.Errorf("LogValue(\"%v\") is %v, want %v", tc.err, str, tc.log)
+		}
+	}
+}
+
+func TestLogValuer(t *testing.T) {
+	err := New("error", WithContext("foo", "bar"))
+	want := `{"level":"ERROR","msg":"test","error":{"msg":"error","type":"*errs.Error","context":{"foo":"bar","function":"github.com/spiegel-im-spiegel/errs.TestLogValuer"}}}`
+	buf := &bytes.Buffer{}
+	newTestLogger(buf, false).Error("test", slog.Any("error", err))
+	if str := strings.TrimSpace(buf.String()); str != want {
+		t.Errorf("log of \"%v\" is %v, want %v", err, str, want)
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	err := fmt.Errorf("outer: %w", New("error", WithContext("foo", "bar")))
+	testCases := []struct {
+		wrap bool
+		log  string
+	}{
+		{wrap: false, log: `{"level":"ERROR","msg":"test","attr":{"error":"outer: error"},"group":{"error":"outer: error","num":1}}`},
+		{wrap: true, log: `{"level":"ERROR","msg":"test","attr":{"error":{"msg":"outer: error","type":"*fmt.wrapError","cause":{"msg":"error","type":"*errs.Error","context":{"foo":"bar","function":"github.com/spiegel-im-spiegel/errs.TestSlogHandler"}}}},"group":{"error":{"msg":"outer: error","type":"*fmt.wrapError","cause":{"msg":"error","type":"*errs.Error","context":{"foo":"bar","function":"github.com/spiegel-im-spiegel/errs.TestSlogHandler"}}},"num":1}}`},
+	}
+
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		logger := newTestLogger(buf, tc.wrap).With(slog.Group("attr", slog.Any("error", err)))
+		logger.Error("test", slog.Group("group", slog.Any("error", err), slog.Int("num", 1)))
+		if str := strings.TrimSpace(buf.String()); str != tc.log {
+			t.Errorf("log of \"%v\" is %v, want %v", err, str, tc.log)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
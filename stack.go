@@ -0,0 +1,134 @@
+package errs
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const (
+	maxStackDepth = 32
+)
+
+//Frame type is a symbolized frame of stack trace.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+//StackTrace type is a list of frames from the innermost (newest) call to the outermost (oldest) one.
+type StackTrace []Frame
+
+//stack type holds program counters captured at creating Error instance. (internal)
+//Program counters are symbolized lazily at first call of frames method.
+type stack struct {
+	pcs    []uintptr
+	once   sync.Once
+	frames StackTrace
+}
+
+//callers returns stack instance of caller. (internal)
+func callers(depth int) *stack {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(depth+2, pcs[:])
+	if n == 0 {
+		return nil
+	}
+	return &stack{pcs: append([]uintptr{}, pcs[:n]...)}
+}
+
+//deferredCallers returns stack instance of function that runs deferred function. (internal)
+//While panicking, it is stack instance of panic site.
+//Frames of the runtime package (runtime.deferreturn, runtime.gopanic, runtime.sigpanic and so on) are skipped.
+func deferredCallers(depth int) *stack {
+	var pcs [maxStackDepth * 2]uintptr
+	n := runtime.Callers(depth+2, pcs[:])
+	start := 0
+	for i, pc := range pcs[:n] {
+		if f := runtime.FuncForPC(pc - 1); f != nil && f.Name() == "runtime.gopanic" {
+			start = i + 1
+			break
+		}
+	}
+	for start < n {
+		if f := runtime.FuncForPC(pcs[start] - 1); f == nil || !strings.HasPrefix(f.Name(), "runtime.") {
+			break
+		}
+		start++
+	}
+	if end := start + maxStackDepth; end < n {
+		n = end
+	}
+	if start >= n {
+		return nil
+	}
+	return &stack{pcs: append([]uintptr{}, pcs[start:n]...)}
+}
+
+//newStack returns stack instance with symbolized frames. (internal)
+func newStack(frames StackTrace) *stack {
+	if len(frames) == 0 {
+		return nil
+	}
+	s := &stack{frames: append(StackTrace{}, frames...)}
+	s.once.Do(func() {})
+	return s
+}
+
+//caller method returns the first frame (caller of New and Wrap functions) without symbolizing whole stack trace. (internal)
+func (s *stack) caller() (Frame, bool) {
+	if s == nil || len(s.pcs) == 0 {
+		return Frame{}, false
+	}
+	frame, _ := runtime.CallersFrames(s.pcs[:1]).Next()
+	if len(frame.Function) == 0 {
+		return Frame{}, false
+	}
+	return Frame{Function: frame.Function, File: frame.File, Line: frame.Line}, true
+}
+
+//StackTrace method returns symbolized stack trace.
+func (s *stack) StackTrace() StackTrace {
+	frames := s.symbolize()
+	if len(frames) == 0 {
+		return nil
+	}
+	return append(StackTrace{}, frames...)
+}
+
+//symbolize method symbolizes program counters at first call and returns frames without copy. (internal)
+func (s *stack) symbolize() StackTrace {
+	if s == nil {
+		return nil
+	}
+	s.once.Do(func() {
+		if len(s.pcs) == 0 {
+			return
+		}
+		frames := runtime.CallersFrames(s.pcs)
+		for {
+			frame, more := frames.Next()
+			s.frames = append(s.frames, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+			if !more {
+				break
+			}
+		}
+	})
+	return s.frames
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
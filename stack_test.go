@@ -0,0 +1,93 @@
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStackTrace(t *testing.T) {
+	testCases := []struct {
+		err  error
+		name string
+	}{
+		{err: New("error"), name: "github.com/spiegel-im-spiegel/errs.TestStackTrace"},
+		{err: Wrap(errors.New("error")), name: "github.com/spiegel-im-spiegel/errs.TestStackTrace"},
+		{err: errTest, name: "github.com/spiegel-im-spiegel/errs.init"},
+	}
+
+	for _, tc := range testCases {
+		e, ok := tc.err.(*Error)
+		if !ok {
+			t.Errorf("Type of \"%v\" is %T, want *Error", tc.err, tc.err)
+			continue
+		}
+		st := e.StackTrace()
+		if len(st) == 0 {
+			t.Errorf("StackTrace(\"%v\") is empty", tc.err)
+			continue
+		}
+		if st[0].Function != tc.name {
+			t.Errorf("StackTrace(\"%v\")[0].Function is %v, want %v", tc.err, st[0].Function, tc.name)
+		}
+		if !strings.HasSuffix(st[0].File, "_test.go") || st[0].Line == 0 {
+			t.Errorf("StackTrace(\"%v\")[0] is %v:%v, want position in test file", tc.err, st[0].File, st[0].Line)
+		}
+	}
+}
+
+func TestStackTraceNil(t *testing.T) {
+	testCases := []struct {
+		err *Error
+	}{
+		{err: nil},
+		{err: &Error{Err: errors.New("error")}},
+	}
+
+	for _, tc := range testCases {
+		if st := tc.err.StackTrace(); st != nil {
+			t.Errorf("StackTrace(\"%v\") is %v, want <nil>", tc.err, st)
+		}
+		if str := fmt.Sprintf("%+v", tc.err); strings.Contains(str, `"StackTrace"`) {
+			t.Errorf("JSON of \"%v\" is %v, want without StackTrace", tc.err, str)
+		}
+	}
+}
+
+func TestStackTraceJSON(t *testing.T) {
+	err := New("error")
+	var v struct {
+		StackTrace StackTrace
+	}
+	if e := json.Unmarshal([]byte(EncodeJSON(err)), &v); e != nil {
+		t.Errorf("json.Unmarshal(\"%v\") is %v, want <nil>", err, e)
+		return
+	}
+	want := err.(*Error).StackTrace()
+	if len(v.StackTrace) != len(want) {
+		t.Errorf("StackTrace in JSON is %v, want %v", v.StackTrace, want)
+		return
+	}
+	for i := range want {
+		if v.StackTrace[i] != want[i] {
+			t.Errorf("StackTrace[%d] in JSON is %v, want %v", i, v.StackTrace[i], want[i])
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
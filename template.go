@@ -0,0 +1,131 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	//templateTypeName is the type name of message template in JSON data. (internal)
+	templateTypeName = "*errs.messageTemplate"
+	//missingSuffix is the marker of placeholder without context value. (internal)
+	missingSuffix = "(MISSING)"
+)
+
+//messageTemplate type is message template of Error instance. (internal)
+//Placeholders such as {path} in the template are filled from Context of the Error instance.
+type messageTemplate struct {
+	tmpl string
+}
+
+var _ error = (*messageTemplate)(nil) //messageTemplate type is compatible with error interface
+
+//Error method returns the raw template.
+func (t *messageTemplate) Error() string {
+	if t == nil {
+		return nilAngleString
+	}
+	return t.tmpl
+}
+
+//NewTemplate function returns an error instance with message template and context informations.
+//Placeholders such as {path} in the template are filled from Context of the instance every time the message is made,
+//so the message and the context never drift apart.
+//Placeholders without context value are rendered as {path(MISSING)}, and "{{" and "}}" are rendered as "{" and "}".
+//The raw template is available by TemplateOf function and as "Template" element of JSON data (a stable grouping key).
+//
+//	err := errs.NewTemplate("cannot open {path} for {user}", errs.WithContext("path", path), errs.WithContext("user", user))
+func NewTemplate(tmpl string, opts ...ErrorContextFunc) error {
+	if len(tmpl) == 0 {
+		return nil
+	}
+	return newError(&messageTemplate{tmpl: tmpl}, false, 2, opts...)
+}
+
+//TemplateOf function finds the first message template in err's tree.
+//TemplateOf function returns empty string if err's tree has no message template.
+func TemplateOf(err error) string {
+	tmpl := ""
+	walk(err, func(n error) bool {
+		if e, ok := n.(*Error); ok && e != nil {
+			if t, ok := e.Err.(*messageTemplate); ok && t != nil {
+				tmpl = t.tmpl
+				return false
+			}
+		}
+		return true
+	})
+	return tmpl
+}
+
+//render method returns message filled from context (redacted by the policy). (internal)
+func (t *messageTemplate) render(context map[string]interface{}, p *RedactionPolicy) string {
+	if t == nil {
+		return nilAngleString
+	}
+	s := t.tmpl
+	if !strings.ContainsAny(s, "{}") {
+		return s
+	}
+	b := &strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '{':
+			if i+1 < len(s) && s[i+1] == '{' {
+				b.WriteByte('{')
+				i++
+				continue
+			}
+			end := strings.IndexByte(s[i+1:], '}')
+			if end < 0 || !isPlaceholder(s[i+1:i+1+end]) {
+				b.WriteByte(c)
+				continue
+			}
+			name := s[i+1 : i+1+end]
+			if v, ok := context[name]; ok {
+				fmt.Fprint(b, p.redactValue(name, v))
+			} else {
+				b.WriteString("{" + name + missingSuffix + "}")
+			}
+			i += end + 1
+		case '}':
+			if i+1 < len(s) && s[i+1] == '}' {
+				i++
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+//isPlaceholder reports whether name is valid name of placeholder (letters, digits, '_', '-' and '.'). (internal)
+func isPlaceholder(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9', r == '_', r == '-', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
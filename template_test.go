@@ -0,0 +1,103 @@
+package errs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestNewTemplate(t *testing.T) {
+	testCases := []struct {
+		err  error
+		msg  string
+		tmpl string
+		json string
+	}{
+		{err: NewTemplate(""), msg: "<nil>", tmpl: ""},
+		{err: NewTemplate("no placeholder"), msg: "no placeholder", tmpl: "no placeholder"},
+		{err: NewTemplate("cannot open {path} for {user}", WithContext("path", "file.txt"), WithContext("user", "alice")), msg: "cannot open file.txt for alice", tmpl: "cannot open {path} for {user}", json: `{"Type":"*errs.Error","Err":{"Type":"*errs.messageTemplate","Msg":"cannot open file.txt for alice"},"Template":"cannot open {path} for {user}","Context":{"function":"github.com/spiegel-im-spiegel/errs.TestNewTemplate","path":"file.txt","user":"alice"}}`},
+		{err: NewTemplate("cannot open {path} for {user}", WithContext("path", "file.txt")), msg: "cannot open file.txt for {user(MISSING)}", tmpl: "cannot open {path} for {user}"},
+		{err: NewTemplate("{count} items, {{literal}}, {not a placeholder}, {}", WithContext("count", 3)), msg: "3 items, {literal}, {not a placeholder}, {}", tmpl: "{count} items, {{literal}}, {not a placeholder}, {}"},
+		{err: NewTemplate("login as {user} with {password}", WithContext("user", "alice"), WithSecret("password", "p@ss")), msg: "login as alice with [REDACTED]", tmpl: "login as {user} with {password}"},
+		{err: NewTemplate("cannot open {path}", WithContext("path", "file.txt"), WithCause(os.ErrNotExist)), msg: "cannot open file.txt: file does not exist", tmpl: "cannot open {path}"},
+		{err: Wrap(NewTemplate("cannot open {path}", WithContext("path", "file.txt")), WithContext("path", "outer.txt")), msg: "cannot open file.txt", tmpl: "cannot open {path}"},
+	}
+	for _, tc := range testCases {
+		if str := fmt.Sprintf("%v", tc.err); str != tc.msg {
+			t.Errorf("NewTemplate() is %q, want %q", str, tc.msg)
+		}
+		if tmpl := TemplateOf(tc.err); tmpl != tc.tmpl {
+			t.Errorf("TemplateOf(\"%v\") is %q, want %q", tc.err, tmpl, tc.tmpl)
+		}
+		if len(tc.json) > 0 {
+			if str := trimStackTrace(EncodeJSON(tc.err)); str != tc.json {
+				t.Errorf("EncodeJSON(\"%v\") is %v, want %v", tc.err, str, tc.json)
+			}
+		}
+	}
+}
+
+func TestTemplateWith(t *testing.T) {
+	base := NewTemplate("cannot open {path}").(*Error).Freeze()
+	if str := base.Error(); str != "cannot open {path(MISSING)}" {
+		t.Errorf("NewTemplate() is %q, want %q", str, "cannot open {path(MISSING)}")
+	}
+	if str := base.With("path", "file.txt").Error(); str != "cannot open file.txt" {
+		t.Errorf("With() is %q, want %q", str, "cannot open file.txt")
+	}
+}
+
+func TestTemplateDecode(t *testing.T) {
+	err := NewTemplate("cannot open {path} ({count})", WithContext("path", "file.txt"), WithContext("count", 2))
+	src := EncodeJSON(err)
+	decoded, decErr := DecodeJSON([]byte(src))
+	if decErr != nil {
+		t.Fatalf("DecodeJSON() is \"%v\", want <nil>", decErr)
+	}
+	if str := decoded.Error(); str != err.Error() {
+		t.Errorf("DecodeJSON() is %q, want %q", str, err.Error())
+	}
+	if tmpl := TemplateOf(decoded); tmpl != "cannot open {path} ({count})" {
+		t.Errorf("TemplateOf(DecodeJSON()) is %q, want %q", tmpl, "cannot open {path} ({count})")
+	}
+	if str := EncodeJSON(decoded); str != src {
+		t.Errorf("EncodeJSON(DecodeJSON()) is %v, want %v", str, src)
+	}
+}
+
+func TestTemplateLogValue(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+	logger.Error("failed", slog.Any("error", NewTemplate("cannot open {path}", WithContext("path", "file.txt"))))
+	var v struct {
+		Error struct {
+			Msg      string `json:"msg"`
+			Template string `json:"template"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatalf("json.Unmarshal() is \"%v\", want <nil>", err)
+	}
+	if v.Error.Msg != "cannot open file.txt" || v.Error.Template != "cannot open {path}" {
+		t.Errorf("log of NewTemplate() is %v, want template and rendered message", strings.TrimSpace(buf.String()))
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */